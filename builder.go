@@ -1,29 +1,34 @@
 package immutableList
 
-type Builder interface {
-	Add(value Object) Builder
+type Builder[T any] interface {
+	Add(value T) Builder[T]
 	Size() int
-	Build() List
+	Build() List[T]
 }
 
-type leafBuilder struct {
-	parent *branchBuilder
+type leafBuilder[T any] struct {
+	config *listConfig[T]
+	parent *branchBuilder[T]
 	count  int // only zero if Add() has never been called
-	buffer [maxValuesPerLeaf]Object
+	buffer []T
 }
 
-type branchBuilder struct {
-	parent *branchBuilder
-	left   node // never nil
-	right  node // may be nil
+type branchBuilder[T any] struct {
+	parent *branchBuilder[T]
+	left   node[T] // never nil
+	right  node[T] // may be nil
 }
 
-func CreateBuilder() Builder {
-	return &leafBuilder{}
+func CreateBuilder[T any](opts ...Option) Builder[T] {
+	return createBuilder(createListConfig[T](opts))
 }
 
-func (this *leafBuilder) Add(value Object) Builder {
-	if this.count == maxValuesPerLeaf {
+func createBuilder[T any](config *listConfig[T]) Builder[T] {
+	return &leafBuilder[T]{config: config, buffer: make([]T, config.leafCapacity)}
+}
+
+func (this *leafBuilder[T]) Add(value T) Builder[T] {
+	if this.count == len(this.buffer) {
 		leafNode := this.createLeafFromBuffer()
 		if this.parent == nil {
 			this.parent = createBranchBuilder(leafNode)
@@ -39,7 +44,7 @@ func (this *leafBuilder) Add(value Object) Builder {
 	return this
 }
 
-func (this *leafBuilder) Size() int {
+func (this *leafBuilder[T]) Size() int {
 	answer := this.count
 	if this.parent != nil {
 		answer += this.parent.computeSize()
@@ -47,29 +52,29 @@ func (this *leafBuilder) Size() int {
 	return answer
 }
 
-func (this *leafBuilder) Build() List {
-	var root node
+func (this *leafBuilder[T]) Build() List[T] {
+	var root node[T]
 	if this.count == 0 {
-		root = createEmptyLeafNode()
+		root = createEmptyLeafNode[T]()
 	} else if this.parent == nil {
 		root = this.createLeafFromBuffer()
 	} else {
-		root = this.parent.build(this.createLeafFromBuffer())
+		root = this.parent.build(this.createLeafFromBuffer(), this.config.leafCapacity)
 	}
-	return createListNode(root)
+	return createListNode(this.config, root)
 }
 
-func (this *leafBuilder) createLeafFromBuffer() node {
-	values := make([]Object, this.count)
+func (this *leafBuilder[T]) createLeafFromBuffer() node[T] {
+	values := make([]T, this.count)
 	copy(values[0:], this.buffer[0:this.count])
 	return createMultiValueLeafNode(values)
 }
 
-func createBranchBuilder(left node) *branchBuilder {
-	return &branchBuilder{left: left}
+func createBranchBuilder[T any](left node[T]) *branchBuilder[T] {
+	return &branchBuilder[T]{left: left}
 }
 
-func (this *branchBuilder) addChild(node node) {
+func (this *branchBuilder[T]) addChild(node node[T]) {
 	if this.right == nil {
 		this.right = node
 	} else {
@@ -84,21 +89,21 @@ func (this *branchBuilder) addChild(node node) {
 	}
 }
 
-func (this *branchBuilder) build(extra node) node {
-	var answer node
+func (this *branchBuilder[T]) build(extra node[T], capacity int) node[T] {
+	var answer node[T]
 	if this.right == nil {
 		answer = this.left
 	} else {
 		answer = createBranchNode(this.left, this.right)
 	}
 	if this.parent != nil {
-		answer = this.parent.build(answer)
+		answer = this.parent.build(answer, capacity)
 	}
-	answer = answer.appendNode(extra)
+	answer = answer.appendNode(extra, capacity)
 	return answer
 }
 
-func (this *branchBuilder) computeSize() int {
+func (this *branchBuilder[T]) computeSize() int {
 	answer := this.left.size()
 	if this.right != nil {
 		answer += this.right.size()
@@ -108,3 +113,75 @@ func (this *branchBuilder) computeSize() int {
 	}
 	return answer
 }
+
+func FromSlice[T any](values []T, opts ...Option) List[T] {
+	config := createListConfig[T](opts)
+	return createListNode(config, createNodeFromSlice(values, config.leafCapacity))
+}
+
+func FromIterator[T any](iterator Iterator[T], opts ...Option) List[T] {
+	config := createListConfig[T](opts)
+	builder := createBalancedBuilder[T](config.leafCapacity)
+	for iterator.Next() {
+		builder.add(iterator.Get())
+	}
+	return createListNode(config, builder.build())
+}
+
+// balancedBuilder collects values into full leaves and assembles them
+// with createBalancedTree once every value has been added.
+type balancedBuilder[T any] struct {
+	leaves []node[T]
+	count  int
+	buffer []T
+}
+
+func createBalancedBuilder[T any](capacity int) *balancedBuilder[T] {
+	return &balancedBuilder[T]{buffer: make([]T, capacity)}
+}
+
+func (this *balancedBuilder[T]) add(value T) {
+	this.buffer[this.count] = value
+	this.count++
+	if this.count == len(this.buffer) {
+		this.leaves = append(this.leaves, createLeafFromValues(this.buffer[0:this.count]))
+		this.count = 0
+	}
+}
+
+func (this *balancedBuilder[T]) build() node[T] {
+	if this.count > 0 {
+		this.leaves = append(this.leaves, createLeafFromValues(this.buffer[0:this.count]))
+		this.count = 0
+	}
+	return createBalancedTree(this.leaves)
+}
+
+func createNodeFromSlice[T any](values []T, capacity int) node[T] {
+	leaves := make([]node[T], 0, (len(values)+capacity-1)/capacity)
+	for offset := 0; offset < len(values); offset += capacity {
+		limit := min(offset+capacity, len(values))
+		leaves = append(leaves, createLeafFromValues(values[offset:limit]))
+	}
+	return createBalancedTree(leaves)
+}
+
+func createLeafFromValues[T any](buffer []T) node[T] {
+	values := make([]T, len(buffer))
+	copy(values, buffer)
+	return createMultiValueLeafNode(values)
+}
+
+// Splitting the leaves in half at every level keeps the depths of
+// sibling subtrees within one of each other so no rotations are needed.
+func createBalancedTree[T any](leaves []node[T]) node[T] {
+	switch len(leaves) {
+	case 0:
+		return createEmptyLeafNode[T]()
+	case 1:
+		return leaves[0]
+	default:
+		mid := len(leaves) / 2
+		return createBranchNode(createBalancedTree(leaves[0:mid]), createBalancedTree(leaves[mid:]))
+	}
+}
@@ -0,0 +1,226 @@
+package immutableList
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Codec converts single values of a List to and from bytes.  Decode must
+// read exactly the bytes written by Encode for the same value.
+type Codec[T any] interface {
+	Encode(w io.Writer, value T) error
+	Decode(r io.Reader) (T, error)
+}
+
+// ErrNoCodec is returned when encoding or decoding a list without a Codec
+// supplied through WithCodec.
+var ErrNoCodec = errors.New("no codec configured")
+
+// The binary format is the number of values as a uvarint followed by each
+// value as written by the list's Codec.
+
+func (this *listImpl[T]) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+	if _, err := this.WriteTo(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func (this *listImpl[T]) WriteTo(w io.Writer) (int64, error) {
+	if this.config.codec == nil {
+		return 0, ErrNoCodec
+	}
+	counter := &countingWriter{w: w}
+	if err := writeUvarint(counter, uint64(this.Size())); err != nil {
+		return counter.count, err
+	}
+	var err error
+	this.root.visit(0, 0, this.Size(), func(_ int, value T) bool {
+		err = this.config.codec.Encode(counter, value)
+		return err != nil
+	})
+	return counter.count, err
+}
+
+// DecodeList reads a list written by List.WriteTo.  opts must include
+// WithCodec with a Codec for T.  Reading stops at the end of the list, so
+// data following it is left in r.
+func DecodeList[T any](r io.Reader, opts ...Option) (List[T], error) {
+	config := createListConfig[T](opts)
+	root, _, err := decodeNode(r, config)
+	if err != nil {
+		return nil, err
+	}
+	return createListNode(config, root), nil
+}
+
+// ListDecoder implements encoding.BinaryUnmarshaler and io.ReaderFrom for
+// lists, which are immutable and so cannot implement them themselves.  Every
+// successful call replaces the List returned by List().
+type ListDecoder[T any] struct {
+	config *listConfig[T]
+	list   List[T]
+}
+
+// CreateListDecoder returns a ListDecoder whose List() is empty until a list
+// has been decoded.  opts must include WithCodec with a Codec for T.
+func CreateListDecoder[T any](opts ...Option) *ListDecoder[T] {
+	config := createListConfig[T](opts)
+	return &ListDecoder[T]{config: config, list: createListNode(config, createEmptyLeafNode[T]())}
+}
+
+func (this *ListDecoder[T]) List() List[T] {
+	return this.list
+}
+
+// UnmarshalBinary decodes data written by List.MarshalBinary and returns an
+// error if data continues past the end of the list.
+func (this *ListDecoder[T]) UnmarshalBinary(data []byte) error {
+	reader := bytes.NewReader(data)
+	root, _, err := decodeNode(reader, this.config)
+	if err != nil {
+		return err
+	}
+	if reader.Len() > 0 {
+		return fmt.Errorf("unexpected data after list: %d bytes", reader.Len())
+	}
+	this.list = createListNode(this.config, root)
+	return nil
+}
+
+// ReadFrom decodes a list written by List.WriteTo, reading r until EOF and
+// returning an error if data continues past the end of the list.  Use
+// DecodeList to read a list followed by other data.
+func (this *ListDecoder[T]) ReadFrom(r io.Reader) (int64, error) {
+	root, count, err := decodeNode(r, this.config)
+	if err != nil {
+		return count, err
+	}
+	var extra [1]byte
+	n, err := io.ReadFull(r, extra[:])
+	count += int64(n)
+	if n > 0 {
+		return count, errors.New("unexpected data after list")
+	}
+	if err != io.EOF {
+		return count, err
+	}
+	this.list = createListNode(this.config, root)
+	return count, nil
+}
+
+func decodeNode[T any](r io.Reader, config *listConfig[T]) (node[T], int64, error) {
+	if config.codec == nil {
+		return nil, 0, ErrNoCodec
+	}
+	counter := &countingReader{r: r}
+	size, err := binary.ReadUvarint(counter)
+	if err != nil {
+		return nil, counter.count, err
+	}
+	builder := createBalancedBuilder[T](config.leafCapacity)
+	for i := uint64(0); i < size; i++ {
+		value, err := config.codec.Decode(counter)
+		if err != nil {
+			return nil, counter.count, err
+		}
+		builder.add(value)
+	}
+	return builder.build(), counter.count, nil
+}
+
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (this *countingWriter) Write(p []byte) (int, error) {
+	n, err := this.w.Write(p)
+	this.count += int64(n)
+	return n, err
+}
+
+// countingReader never reads ahead so the underlying reader is left
+// positioned just after the list.
+type countingReader struct {
+	r     io.Reader
+	count int64
+}
+
+func (this *countingReader) Read(p []byte) (int, error) {
+	n, err := this.r.Read(p)
+	this.count += int64(n)
+	return n, err
+}
+
+func (this *countingReader) ReadByte() (byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(this, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// StringCodec encodes strings as a uvarint length followed by their bytes.
+type StringCodec struct {
+}
+
+func (StringCodec) Encode(w io.Writer, value string) error {
+	if err := writeUvarint(w, uint64(len(value))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, value)
+	return err
+}
+
+func (StringCodec) Decode(r io.Reader) (string, error) {
+	length, err := binary.ReadUvarint(asByteReader(r))
+	if err != nil {
+		return "", err
+	}
+	value := make([]byte, length)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// FixedSizeCodec encodes fixed size values such as numbers, or structs and
+// arrays containing only fixed size values, using encoding/binary.
+type FixedSizeCodec[T any] struct {
+	Order binary.ByteOrder // defaults to binary.LittleEndian
+}
+
+func (this FixedSizeCodec[T]) Encode(w io.Writer, value T) error {
+	return binary.Write(w, this.byteOrder(), value)
+}
+
+func (this FixedSizeCodec[T]) Decode(r io.Reader) (T, error) {
+	var value T
+	err := binary.Read(r, this.byteOrder(), &value)
+	return value, err
+}
+
+func (this FixedSizeCodec[T]) byteOrder() binary.ByteOrder {
+	if this.Order == nil {
+		return binary.LittleEndian
+	}
+	return this.Order
+}
+
+func writeUvarint(w io.Writer, value uint64) error {
+	var buffer [binary.MaxVarintLen64]byte
+	_, err := w.Write(buffer[:binary.PutUvarint(buffer[:], value)])
+	return err
+}
+
+func asByteReader(r io.Reader) io.ByteReader {
+	if byteReader, matches := r.(io.ByteReader); matches {
+		return byteReader
+	}
+	return &countingReader{r: r}
+}
@@ -0,0 +1,114 @@
+package immutableList
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, length := range []int{0, 1, 32, 33, 1000} {
+		list := FromSlice(createListForTest(1, length).Slice(0, length), WithCodec[string](StringCodec{}))
+		var marshaler encoding.BinaryMarshaler = list
+		data, err := marshaler.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		decoder := CreateListDecoder[string](WithCodec[string](StringCodec{}))
+		var unmarshaler encoding.BinaryUnmarshaler = decoder
+		if err := unmarshaler.UnmarshalBinary(data); err != nil {
+			t.Error(err)
+			continue
+		}
+		decoded := decoder.List()
+		validateList(t, decoded, length)
+		validateMinimumDepth(t, decoded, length)
+
+		read, err := DecodeList[string](bytes.NewReader(data), WithCodec[string](StringCodec{}))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		validateList(t, read, length)
+	}
+}
+
+func TestWriterToReaderFrom(t *testing.T) {
+	codec := WithCodec[int64](FixedSizeCodec[int64]{})
+	list := FromSlice([]int64{5, -3, 1 << 40}, codec)
+	var buffer bytes.Buffer
+	var writer io.WriterTo = list
+	written, err := writer.WriteTo(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+
+	decoder := CreateListDecoder[int64](codec)
+	var reader io.ReaderFrom = decoder
+	read, err := reader.ReadFrom(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateSize(t, int(read), int(written))
+	decoded := decoder.List()
+	validateSize(t, decoded.Size(), 3)
+	if decoded.Get(0) != 5 || decoded.Get(1) != -3 || decoded.Get(2) != 1<<40 {
+		t.Error("decoded values do not match")
+	}
+
+	trailing := bytes.NewReader(append(data, "trailing"...))
+	if _, err := reader.ReadFrom(trailing); err == nil {
+		t.Error("expected error for trailing data")
+	}
+	if decoder.List() != decoded {
+		t.Error("expected failed ReadFrom to keep the previous list")
+	}
+	stream := bytes.NewBuffer(append(data, "trailing"...))
+	if _, err := DecodeList[int64](stream, codec); err != nil {
+		t.Error(err)
+	}
+	if stream.String() != "trailing" {
+		t.Error("expected DecodeList to stop at the end of the list")
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	if _, err := createListForTest(1, 10).MarshalBinary(); !errors.Is(err, ErrNoCodec) {
+		t.Error("expected ErrNoCodec for list without codec")
+	}
+	if _, err := DecodeList[string](bytes.NewReader([]byte{0})); !errors.Is(err, ErrNoCodec) {
+		t.Error("expected ErrNoCodec decoding without codec")
+	}
+	list := createListForTest(1, 10)
+	data, _ := FromSlice(list.Slice(0, 10), WithCodec[string](StringCodec{})).MarshalBinary()
+	decoder := CreateListDecoder[string](WithCodec[string](StringCodec{}))
+	if err := decoder.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Error("expected error for truncated data")
+	}
+	if err := decoder.UnmarshalBinary(append(data, 0)); err == nil {
+		t.Error("expected error for trailing data")
+	}
+	validateSize(t, decoder.List().Size(), 0)
+}
+
+func TestListDecoderLeavesListsUnchanged(t *testing.T) {
+	codec := WithCodec[string](StringCodec{})
+	data, _ := FromSlice(createListForTest(1, 10).Slice(0, 10), codec).MarshalBinary()
+	decoder := CreateListDecoder[string](codec)
+	empty := decoder.List()
+	if err := decoder.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	first := decoder.List()
+	if err := decoder.UnmarshalBinary(data[:1+len(data)/2]); err == nil {
+		t.Error("expected error for truncated data")
+	}
+	validateSize(t, empty.Size(), 0)
+	validateList(t, first, 10)
+	validateList(t, decoder.List(), 10)
+}
@@ -0,0 +1,95 @@
+package immutableList
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteTree writes an indented outline of the nodes of list to w, one node
+// per line with the children of a branch below it.  Nodes that are also
+// part of any of the lists in compareWith are marked as shared.
+func WriteTree[T any](w io.Writer, list List[T], compareWith ...List[T]) error {
+	shared := collectSharedNodes(compareWith)
+	impl := list.(*listImpl[T])
+	buffered := bufio.NewWriter(w)
+	writeTreeNode(buffered, impl.root, 0, impl.config.leafCapacity, shared)
+	return buffered.Flush()
+}
+
+func writeTreeNode[T any](w *bufio.Writer, n node[T], indent int, capacity int, shared map[node[T]]bool) {
+	w.WriteString(strings.Repeat("  ", indent))
+	w.WriteString(describeNode(n, capacity))
+	if shared[n] {
+		w.WriteString(" shared")
+	}
+	w.WriteByte('\n')
+	if n.depth() > 0 {
+		writeTreeNode(w, n.left(), indent+1, capacity, shared)
+		writeTreeNode(w, n.right(), indent+1, capacity, shared)
+	}
+}
+
+// WriteDot writes the nodes of list to w as a Graphviz digraph.  Nodes that
+// are also part of any of the lists in compareWith are filled.
+func WriteDot[T any](w io.Writer, list List[T], compareWith ...List[T]) error {
+	shared := collectSharedNodes(compareWith)
+	ids := make(map[node[T]]int)
+	buffered := bufio.NewWriter(w)
+	buffered.WriteString("digraph list {\n")
+	buffered.WriteString("  node [shape=box];\n")
+	impl := list.(*listImpl[T])
+	writeDotNode(buffered, impl.root, impl.config.leafCapacity, ids, shared)
+	buffered.WriteString("}\n")
+	return buffered.Flush()
+}
+
+func writeDotNode[T any](w *bufio.Writer, n node[T], capacity int, ids map[node[T]]int, shared map[node[T]]bool) int {
+	if id, found := ids[n]; found {
+		return id
+	}
+	id := len(ids) + 1
+	ids[n] = id
+	style := ""
+	if shared[n] {
+		style = ", style=filled, fillcolor=lightblue"
+	}
+	fmt.Fprintf(w, "  n%d [label=%q%s];\n", id, describeNode(n, capacity), style)
+	if n.depth() > 0 {
+		leftId := writeDotNode(w, n.left(), capacity, ids, shared)
+		rightId := writeDotNode(w, n.right(), capacity, ids, shared)
+		fmt.Fprintf(w, "  n%d -> n%d;\n", id, leftId)
+		fmt.Fprintf(w, "  n%d -> n%d;\n", id, rightId)
+	}
+	return id
+}
+
+func describeNode[T any](n node[T], capacity int) string {
+	if n.depth() > 0 {
+		return fmt.Sprintf("branch size=%d depth=%d", n.size(), n.depth())
+	} else if n.size() > 0 {
+		return fmt.Sprintf("leaf %d/%d", n.size(), capacity)
+	} else {
+		return "empty"
+	}
+}
+
+func collectSharedNodes[T any](lists []List[T]) map[node[T]]bool {
+	answer := make(map[node[T]]bool)
+	for _, list := range lists {
+		collectNodes(list.(*listImpl[T]).root, answer)
+	}
+	return answer
+}
+
+func collectNodes[T any](n node[T], nodes map[node[T]]bool) {
+	if n.size() == 0 || nodes[n] {
+		return
+	}
+	nodes[n] = true
+	if n.depth() > 0 {
+		collectNodes(n.left(), nodes)
+		collectNodes(n.right(), nodes)
+	}
+}
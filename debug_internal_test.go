@@ -0,0 +1,49 @@
+package immutableList
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWriteTree(t *testing.T) {
+	list := createListForTest(1, 40)
+	var buffer bytes.Buffer
+	if err := WriteTree(&buffer, list); err != nil {
+		t.Fatal(err)
+	}
+	validateFormat(t, buffer.String(), "branch size=40 depth=1\n  leaf 32/32\n  leaf 8/32\n")
+
+	buffer.Reset()
+	if err := WriteTree(&buffer, list.Set(39, val(0)), list); err != nil {
+		t.Fatal(err)
+	}
+	validateFormat(t, buffer.String(), "branch size=40 depth=1\n  leaf 32/32 shared\n  leaf 8/32\n")
+
+	buffer.Reset()
+	if err := WriteTree(&buffer, Create[string]()); err != nil {
+		t.Fatal(err)
+	}
+	validateFormat(t, buffer.String(), "empty\n")
+}
+
+func TestWriteDot(t *testing.T) {
+	list := createListForTest(1, 1000)
+	changed := list.Set(500, val(0))
+	var buffer bytes.Buffer
+	if err := WriteDot(&buffer, changed, list); err != nil {
+		t.Fatal(err)
+	}
+	dot := buffer.String()
+	if !strings.HasPrefix(dot, "digraph list {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Error(fmt.Sprintf("unexpected dot output: %s", dot))
+	}
+	if !strings.Contains(dot, `n1 [label="branch size=1000 depth=5"];`) {
+		t.Error(fmt.Sprintf("expected root node in dot output: %s", dot))
+	}
+	nodeCount := strings.Count(dot, "[label=")
+	edgeCount := strings.Count(dot, " -> ")
+	validateSize(t, edgeCount, nodeCount-1)
+	validateSize(t, strings.Count(dot, "fillcolor"), nodeCount-6)
+}
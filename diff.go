@@ -0,0 +1,254 @@
+package immutableList
+
+type EditOp int
+
+const (
+	EditInsert EditOp = iota
+	EditDelete
+	EditReplace
+)
+
+// Edit describes one change needed to turn the old list into the new list.
+// Values in [OldOffset, OldLimit) of the old list are replaced by the values
+// in [NewOffset, NewLimit) of the new list.  Inserts have an empty old range
+// and deletes have an empty new range.
+type Edit struct {
+	Op        EditOp
+	OldOffset int
+	OldLimit  int
+	NewOffset int
+	NewLimit  int
+}
+
+// Diff returns the edits that turn old into new in order of increasing offset.
+// Subtrees shared by both lists are treated as single units and never visited,
+// so diffing two versions derived from one another costs time proportional to
+// the size of the change rather than the size of the lists.
+func Diff[T any](old List[T], new List[T], eq func(a, b T) bool) []Edit {
+	oldRoot, newRoot := old.(*listImpl[T]).root, new.(*listImpl[T]).root
+	if oldRoot == newRoot {
+		return nil
+	}
+	oldTokens, newTokens := createDiffTokens(oldRoot, newRoot)
+	return createEdits(diffTokens(oldTokens, newTokens, eq))
+}
+
+// A diffToken is either a subtree found in both lists or a single value
+// from a leaf found in only one of them.
+type diffToken[T any] struct {
+	shared node[T]
+	value  T
+}
+
+func (t diffToken[T]) size() int {
+	if t.shared != nil {
+		return t.shared.size()
+	}
+	return 1
+}
+
+func (t diffToken[T]) equal(other diffToken[T], eq func(a, b T) bool) bool {
+	if t.shared != nil || other.shared != nil {
+		return t.shared == other.shared
+	}
+	return eq(t.value, other.value)
+}
+
+// The depth of a node never changes, so a subtree shared by both lists appears
+// in both frontiers when they are expanded one depth at a time from the top.
+// Only nodes missing from the other frontier are expanded.
+func createDiffTokens[T any](oldRoot node[T], newRoot node[T]) ([]diffToken[T], []diffToken[T]) {
+	oldFrontier, newFrontier := createDiffFrontier(oldRoot), createDiffFrontier(newRoot)
+	for depth := maxDepth(oldRoot, newRoot); depth > 0; depth-- {
+		oldNodes, newNodes := diffFrontierNodes(oldFrontier, depth), diffFrontierNodes(newFrontier, depth)
+		oldFrontier = expandDiffFrontier(oldFrontier, depth, newNodes)
+		newFrontier = expandDiffFrontier(newFrontier, depth, oldNodes)
+	}
+	oldLeaves, newLeaves := diffFrontierNodes(oldFrontier, 0), diffFrontierNodes(newFrontier, 0)
+	return createDiffTokensFromLeaves(oldFrontier, newLeaves), createDiffTokensFromLeaves(newFrontier, oldLeaves)
+}
+
+func createDiffFrontier[T any](root node[T]) []node[T] {
+	if root.size() == 0 {
+		return nil
+	}
+	return []node[T]{root}
+}
+
+func diffFrontierNodes[T any](frontier []node[T], depth int) map[node[T]]bool {
+	answer := make(map[node[T]]bool)
+	for _, n := range frontier {
+		if n.depth() == depth {
+			answer[n] = true
+		}
+	}
+	return answer
+}
+
+func expandDiffFrontier[T any](frontier []node[T], depth int, otherNodes map[node[T]]bool) []node[T] {
+	answer := make([]node[T], 0, len(frontier))
+	for _, n := range frontier {
+		if n.depth() == depth && !otherNodes[n] {
+			answer = append(answer, n.left(), n.right())
+		} else {
+			answer = append(answer, n)
+		}
+	}
+	return answer
+}
+
+func createDiffTokensFromLeaves[T any](frontier []node[T], otherLeaves map[node[T]]bool) []diffToken[T] {
+	answer := make([]diffToken[T], 0, len(frontier))
+	for _, n := range frontier {
+		if n.depth() > 0 || otherLeaves[n] {
+			answer = append(answer, diffToken[T]{shared: n})
+		} else {
+			n.visit(0, 0, n.size(), func(_ int, value T) bool {
+				answer = append(answer, diffToken[T]{value: value})
+				return false
+			})
+		}
+	}
+	return answer
+}
+
+type diffStep[T any] struct {
+	op    EditOp // EditInsert or EditDelete, ignored when equal is true
+	equal bool
+	size  int
+}
+
+// Myers' O((N+M)D) shortest edit script over the two token sequences using
+// the linear space refinement: each range is split at the middle snake of an
+// optimal path and both halves are solved recursively, so only the furthest
+// x reached on each diagonal is kept rather than one row per edit distance.
+func diffTokens[T any](a []diffToken[T], b []diffToken[T], eq func(a, b T) bool) []diffStep[T] {
+	context := &diffContext[T]{
+		a:        a,
+		b:        b,
+		eq:       eq,
+		forward:  make([]int, len(a)+len(b)+4),
+		backward: make([]int, len(a)+len(b)+4),
+	}
+	context.diffRange(0, len(a), 0, len(b))
+	return context.steps
+}
+
+type diffContext[T any] struct {
+	a        []diffToken[T]
+	b        []diffToken[T]
+	eq       func(a, b T) bool
+	forward  []int
+	backward []int
+	steps    []diffStep[T]
+}
+
+func (c *diffContext[T]) diffRange(aStart int, aLimit int, bStart int, bLimit int) {
+	for aStart < aLimit && bStart < bLimit && c.a[aStart].equal(c.b[bStart], c.eq) {
+		c.steps = append(c.steps, diffStep[T]{equal: true, size: c.a[aStart].size()})
+		aStart++
+		bStart++
+	}
+	suffixLimit := aLimit
+	for aStart < aLimit && bStart < bLimit && c.a[aLimit-1].equal(c.b[bLimit-1], c.eq) {
+		aLimit--
+		bLimit--
+	}
+
+	if aStart == aLimit {
+		for _, token := range c.b[bStart:bLimit] {
+			c.steps = append(c.steps, diffStep[T]{op: EditInsert, size: token.size()})
+		}
+	} else if bStart == bLimit {
+		for _, token := range c.a[aStart:aLimit] {
+			c.steps = append(c.steps, diffStep[T]{op: EditDelete, size: token.size()})
+		}
+	} else {
+		x, y := c.middleSnake(aStart, aLimit, bStart, bLimit)
+		c.diffRange(aStart, aStart+x, bStart, bStart+y)
+		c.diffRange(aStart+x, aLimit, bStart+y, bLimit)
+	}
+
+	for _, token := range c.a[aLimit:suffixLimit] {
+		c.steps = append(c.steps, diffStep[T]{equal: true, size: token.size()})
+	}
+}
+
+// Runs the forward search from the start of both ranges and the reverse search
+// from their ends until the furthest reaching paths overlap, returning a point
+// on an optimal path relative to aStart and bStart.  The ranges must differ in
+// their first and last tokens so the point is never at either end.  The
+// reverse search counts x backwards from aLimit, so forward diagonal k
+// corresponds to reverse diagonal delta-k.
+func (c *diffContext[T]) middleSnake(aStart int, aLimit int, bStart int, bLimit int) (int, int) {
+	n, m := aLimit-aStart, bLimit-bStart
+	delta := n - m
+	odd := delta%2 != 0
+	offset := (n+m+1)/2 + 1
+	c.forward[offset+1] = 0
+	c.backward[offset+1] = 0
+	for d := 0; d <= (n+m+1)/2; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := furthestOnDiagonal(c.forward, offset, d, k)
+			y := x - k
+			for x < n && y < m && c.a[aStart+x].equal(c.b[bStart+y], c.eq) {
+				x++
+				y++
+			}
+			c.forward[offset+k] = x
+			if odd && delta-k >= -(d-1) && delta-k <= d-1 && x+c.backward[offset+delta-k] >= n {
+				return x, y
+			}
+		}
+		for k := -d; k <= d; k += 2 {
+			x := furthestOnDiagonal(c.backward, offset, d, k)
+			y := x - k
+			for x < n && y < m && c.a[aLimit-1-x].equal(c.b[bLimit-1-y], c.eq) {
+				x++
+				y++
+			}
+			c.backward[offset+k] = x
+			if !odd && delta-k >= -d && delta-k <= d && x+c.forward[offset+delta-k] >= n {
+				return n - x, m - y
+			}
+		}
+	}
+	panic("diff did not terminate")
+}
+
+// returns the x reached on diagonal k after d edits before following its snake,
+// preferring an insert from diagonal k+1 over a delete from diagonal k-1
+func furthestOnDiagonal(furthest []int, offset int, d int, k int) int {
+	if k == -d || (k != d && furthest[offset+k-1] < furthest[offset+k+1]) {
+		return furthest[offset+k+1]
+	}
+	return furthest[offset+k-1] + 1
+}
+
+func createEdits[T any](steps []diffStep[T]) []Edit {
+	answer := make([]Edit, 0)
+	oldOffset, oldLimit, newOffset, newLimit := 0, 0, 0, 0
+	flush := func() {
+		if oldLimit > oldOffset && newLimit > newOffset {
+			answer = append(answer, Edit{EditReplace, oldOffset, oldLimit, newOffset, newLimit})
+		} else if oldLimit > oldOffset {
+			answer = append(answer, Edit{EditDelete, oldOffset, oldLimit, newOffset, newLimit})
+		} else if newLimit > newOffset {
+			answer = append(answer, Edit{EditInsert, oldOffset, oldLimit, newOffset, newLimit})
+		}
+	}
+	for _, step := range steps {
+		if step.equal {
+			flush()
+			oldLimit += step.size
+			newLimit += step.size
+			oldOffset, newOffset = oldLimit, newLimit
+		} else if step.op == EditDelete {
+			oldLimit += step.size
+		} else {
+			newLimit += step.size
+		}
+	}
+	flush()
+	return answer
+}
@@ -0,0 +1,128 @@
+package immutableList
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	for loop := 0; loop < 200; loop++ {
+		old := createListForTest(1, rand.Intn(3000))
+		new := old
+		for edits := rand.Intn(6); edits >= 0; edits-- {
+			switch rand.Intn(4) {
+			case 0:
+				new = new.Insert(rand.Intn(new.Size()+1), val(-loop))
+			case 1:
+				if new.Size() > 0 {
+					new = new.Delete(rand.Intn(new.Size()))
+				}
+			case 2:
+				if new.Size() > 0 {
+					new = new.Set(rand.Intn(new.Size()), val(-loop))
+				}
+			default:
+				offset := rand.Intn(new.Size() + 1)
+				new = new.InsertList(offset, createListForTest(10000, 10000+rand.Intn(100)))
+			}
+		}
+		validateDiff(t, old, new)
+	}
+	validateDiff(t, Create[string](), createListForTest(1, 100))
+	validateDiff(t, createListForTest(1, 100), Create[string]())
+	validateDiff(t, createListForTest(1, 100), createListForTest(50, 150))
+	validateDiff(t, createListForTest(1, 100), createListForTestDirectly(1, 100))
+}
+
+func TestDiffSkipsSharedNodes(t *testing.T) {
+	old := createListForTest(1, 100000)
+	new := old.Set(5000, val(0)).Insert(70000, val(0)).Delete(90000)
+	calls := 0
+	edits := Diff(old, new, func(a, b string) bool {
+		calls++
+		return a == b
+	})
+	validateSize(t, len(edits), 3)
+	if calls > 20*defaultLeafCapacity {
+		t.Error(fmt.Sprintf("expected shared nodes to be skipped but eq was called %d times", calls))
+	}
+	validateDiff(t, old, new)
+}
+
+func TestDiffIsMinimal(t *testing.T) {
+	for loop := 0; loop < 200; loop++ {
+		old, new := make([]string, rand.Intn(60)), make([]string, rand.Intn(60))
+		for i := range old {
+			old[i] = val(rand.Intn(5))
+		}
+		for i := range new {
+			new[i] = val(rand.Intn(5))
+		}
+		edits := Diff(FromSlice(old), FromSlice(new), func(a, b string) bool { return a == b })
+		cost := 0
+		for _, edit := range edits {
+			cost += edit.OldLimit - edit.OldOffset + edit.NewLimit - edit.NewOffset
+		}
+		validateSize(t, cost, len(old)+len(new)-2*longestCommonSubsequence(old, new))
+	}
+}
+
+func TestDiffUnrelatedLists(t *testing.T) {
+	old := createListForTest(1, 6000)
+	new := createListForTest(10001, 16000).Map(func(value string) string { return value })
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	edits := Diff(old, new, func(a, b string) bool { return a == b })
+	runtime.ReadMemStats(&after)
+	validateSize(t, len(edits), 1)
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 16<<20 {
+		t.Error(fmt.Sprintf("expected diff to use linear space but it allocated %d bytes", allocated))
+	}
+	validateDiff(t, old, new)
+}
+
+func longestCommonSubsequence(a []string, b []string) int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	return lengths[0][0]
+}
+
+func validateDiff(t *testing.T, old List[string], new List[string]) {
+	edits := Diff(old, new, func(a, b string) bool { return a == b })
+	oldValues, newValues := old.Slice(0, old.Size()), new.Slice(0, new.Size())
+	actual := make([]string, 0)
+	oldPos := 0
+	for _, edit := range edits {
+		if edit.OldOffset < oldPos || edit.NewOffset-edit.OldOffset != len(actual)-oldPos {
+			t.Error(fmt.Sprintf("edit out of order: %v", edit))
+			return
+		}
+		expectedOp := EditReplace
+		if edit.OldOffset == edit.OldLimit {
+			expectedOp = EditInsert
+		} else if edit.NewOffset == edit.NewLimit {
+			expectedOp = EditDelete
+		}
+		if edit.Op != expectedOp {
+			t.Error(fmt.Sprintf("edit has wrong op: %v", edit))
+		}
+		actual = append(actual, oldValues[oldPos:edit.OldOffset]...)
+		actual = append(actual, newValues[edit.NewOffset:edit.NewLimit]...)
+		oldPos = edit.OldLimit
+	}
+	actual = append(actual, oldValues[oldPos:]...)
+	validateList3(t, FromSlice(actual), newValues)
+}
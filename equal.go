@@ -0,0 +1,49 @@
+package immutableList
+
+// Equal reports whether both lists contain the same values in the same order
+// according to eq.  Subtrees shared by the two lists are recognized by pointer
+// identity and never visited, so comparing a list with a version derived from
+// it only costs time proportional to the nodes that differ.
+func (this *listImpl[T]) Equal(other List[T], eq func(a, b T) bool) bool {
+	otherImpl := other.(*listImpl[T])
+	if this.Size() != otherImpl.Size() {
+		return false
+	}
+	return equalNodes(this.root, 0, otherImpl.root, 0, this.Size(), eq)
+}
+
+// compares length values of a starting at aStart with those of b starting at bStart
+// by splitting the deeper node at its child boundary until the ranges line up
+func equalNodes[T any](a node[T], aStart int, b node[T], bStart int, length int, eq func(a, b T) bool) bool {
+	if length == 0 || (a == b && aStart == bStart) {
+		return true
+	}
+	if a.depth() > 0 && a.depth() >= b.depth() {
+		return equalSplitNode(a, aStart, length, func(child node[T], childStart int, offset int, childLength int) bool {
+			return equalNodes(child, childStart, b, bStart+offset, childLength, eq)
+		})
+	}
+	if b.depth() > 0 {
+		return equalSplitNode(b, bStart, length, func(child node[T], childStart int, offset int, childLength int) bool {
+			return equalNodes(a, aStart+offset, child, childStart, childLength, eq)
+		})
+	}
+	for i := 0; i < length; i++ {
+		if !eq(a.get(aStart+i), b.get(bStart+i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalSplitNode[T any](n node[T], start int, length int, compare func(child node[T], childStart int, offset int, childLength int) bool) bool {
+	leftSize := n.left().size()
+	if start+length <= leftSize {
+		return compare(n.left(), start, 0, length)
+	}
+	if start >= leftSize {
+		return compare(n.right(), start-leftSize, 0, length)
+	}
+	leftLength := leftSize - start
+	return compare(n.left(), start, 0, leftLength) && compare(n.right(), 0, leftLength, length-leftLength)
+}
@@ -0,0 +1,40 @@
+package immutableList
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	eq := func(a, b string) bool { return a == b }
+	for _, length := range []int{0, 1, 32, 33, 500, 2000} {
+		built := createListForTest(1, length)
+		appended := createListForTestDirectly(1, length)
+		prepended := createListForTestReverseDirectly(1, length)
+		if !built.Equal(appended, eq) || !appended.Equal(prepended, eq) || !prepended.Equal(built, eq) {
+			t.Error("expected lists with different shapes to be equal")
+		}
+		if length > 0 {
+			changed := appended.Set(length/2, val(0))
+			if built.Equal(changed, eq) || changed.Equal(prepended, eq) {
+				t.Error("expected lists with different values to not be equal")
+			}
+		}
+		if built.Equal(built.Append(val(length+1)), eq) {
+			t.Error("expected lists with different sizes to not be equal")
+		}
+	}
+}
+
+func TestEqualSkipsSharedNodes(t *testing.T) {
+	original := createListForTest(1, 100000)
+	changed := original.Set(5000, val(0)).Set(5000, val(5001))
+	calls := 0
+	equal := original.Equal(changed, func(a, b string) bool {
+		calls++
+		return a == b
+	})
+	if !equal {
+		t.Error("expected lists to be equal")
+	}
+	if calls > defaultLeafCapacity {
+		t.Error("expected shared nodes to be skipped")
+	}
+}
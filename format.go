@@ -0,0 +1,57 @@
+package immutableList
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// String formats the list like a slice using %v.
+func (this *listImpl[T]) String() string {
+	return fmt.Sprintf("%v", this)
+}
+
+// Format prints the values like fmt prints a slice, applying the verb and
+// flags to every value.  The %+v form is prefixed with the size and depth
+// of the list.  Values beyond the format limit are summarized as "... N more".
+func (this *listImpl[T]) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "size=%d depth=%d ", this.Size(), this.root.depth())
+	}
+	valueFormat := fmt.FormatString(f, verb)
+	shown := this.formatCount()
+	f.Write([]byte{'['})
+	this.root.visit(0, 0, shown, func(index int, value T) bool {
+		if index > 0 {
+			f.Write([]byte{' '})
+		}
+		fmt.Fprintf(f, valueFormat, value)
+		return false
+	})
+	if shown < this.Size() {
+		if shown > 0 {
+			f.Write([]byte{' '})
+		}
+		fmt.Fprintf(f, "... %d more", this.Size()-shown)
+	}
+	f.Write([]byte{']'})
+}
+
+// LogValue logs the size of the list and its values up to the format limit.
+func (this *listImpl[T]) LogValue() slog.Value {
+	shown := this.formatCount()
+	attrs := []slog.Attr{
+		slog.Int("size", this.Size()),
+		slog.Any("values", this.Slice(0, shown)),
+	}
+	if shown < this.Size() {
+		attrs = append(attrs, slog.Int("more", this.Size()-shown))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func (this *listImpl[T]) formatCount() int {
+	if this.config.formatLimit < 0 || this.config.formatLimit > this.Size() {
+		return this.Size()
+	}
+	return this.config.formatLimit
+}
@@ -0,0 +1,48 @@
+package immutableList
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	list := FromSlice([]int{1, 2, 3})
+	validateFormat(t, list.String(), "[1 2 3]")
+	validateFormat(t, fmt.Sprintf("%v", list), "[1 2 3]")
+	validateFormat(t, fmt.Sprintf("%03d", list), "[001 002 003]")
+	validateFormat(t, fmt.Sprintf("%+v", list), "size=3 depth=0 [1 2 3]")
+	validateFormat(t, fmt.Sprintf("%q", FromSlice([]string{"a", "b"})), `["a" "b"]`)
+	validateFormat(t, Create[int]().String(), "[]")
+
+	long := FromSlice(make([]int, 1000), WithFormatLimit(3))
+	validateFormat(t, long.String(), "[0 0 0 ... 997 more]")
+	validateFormat(t, long.Append(5).String(), "[0 0 0 ... 998 more]")
+	validateFormat(t, FromSlice(make([]int, 5), WithFormatLimit(0)).String(), "[... 5 more]")
+	validateFormat(t, FromSlice(make([]int, 5), WithFormatLimit(-1)).String(), "[0 0 0 0 0]")
+	if !strings.HasSuffix(FromSlice(make([]int, 150)).String(), " ... 50 more]") {
+		t.Error("expected default limit of 100 values")
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buffer, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("test", "list", FromSlice([]int{1, 2, 3, 4, 5}, WithFormatLimit(2)))
+	validateFormat(t, buffer.String(), "level=INFO msg=test list.size=5 list.values=\"[1 2]\" list.more=3\n")
+}
+
+func validateFormat(t *testing.T, actual string, expected string) {
+	if actual != expected {
+		t.Error(fmt.Sprintf("expected %q but got %q", expected, actual))
+	}
+}
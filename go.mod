@@ -1 +1,3 @@
 module immutableList
+
+go 1.23
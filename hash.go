@@ -0,0 +1,74 @@
+package immutableList
+
+const hashMultiplier uint64 = 0x100000001b3
+
+// The content hash of a sequence v[0..n) is sum(mix(hasher(v[i])) * hashMultiplier^(n-1-i)).
+// Keeping hashMultiplier^n alongside the sum lets a branch combine the hashes of
+// its children without looking at their values, and makes the result independent
+// of how the values happen to be split into leaves and branches.
+type nodeHash[T any] struct {
+	token *hasherToken
+	sum   uint64
+	power uint64
+}
+
+// Every WithHasher call allocates its own token holding the hasher.  Cached
+// node hashes are tagged with the token, so lists created with the same
+// option share them even though each list has its own config.
+type hasherToken struct {
+	hasher any
+}
+
+// Hash returns a hash of the values in the list.  Lists containing equal values
+// in the same order have the same hash regardless of how they were built.  The
+// hash of every node is cached so only nodes created since the last call need
+// to be hashed again.  Panics unless the list was created using WithHasher.
+func (this *listImpl[T]) Hash() uint64 {
+	if this.config.hasher == nil {
+		panic("Hash called on List created without a hasher")
+	}
+	hash := this.root.contentHash(this.config)
+	return mixHash(hash.sum ^ uint64(this.root.size()))
+}
+
+func (a *leafNode[T]) contentHash(config *listConfig[T]) *nodeHash[T] {
+	if cached := a.hash.Load(); cached != nil && cached.token == config.hasherToken {
+		return cached
+	}
+	answer := &nodeHash[T]{token: config.hasherToken, power: 1}
+	for _, value := range a.values {
+		answer.sum = answer.sum*hashMultiplier + mixHash(config.hasher(value))
+		answer.power *= hashMultiplier
+	}
+	a.hash.Store(answer)
+	return answer
+}
+
+func (e *emptyNode[T]) contentHash(config *listConfig[T]) *nodeHash[T] {
+	return &nodeHash[T]{token: config.hasherToken, power: 1}
+}
+
+func (b *branchNode[T]) contentHash(config *listConfig[T]) *nodeHash[T] {
+	if cached := b.hash.Load(); cached != nil && cached.token == config.hasherToken {
+		return cached
+	}
+	left := b.leftChild.contentHash(config)
+	right := b.rightChild.contentHash(config)
+	answer := &nodeHash[T]{
+		token: config.hasherToken,
+		sum:   left.sum*right.power + right.sum,
+		power: left.power * right.power,
+	}
+	b.hash.Store(answer)
+	return answer
+}
+
+// finalizer from splitmix64 to spread the bits of user supplied hashes
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
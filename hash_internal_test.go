@@ -0,0 +1,86 @@
+package immutableList
+
+import (
+	"hash/fnv"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	for _, length := range []int{0, 1, 32, 33, 500, 2000} {
+		built := createListForTestWithHasher(1, length)
+		direct := CreateWithOptions[string](WithHasher(hashString))
+		for i := length; i >= 1; i-- {
+			direct = direct.Push(val(i))
+		}
+		if built.Hash() != direct.Hash() {
+			t.Error("expected lists with different shapes to have equal hashes")
+		}
+		if built.Hash() == built.Append(val(0)).Hash() {
+			t.Error("expected appended list to have a different hash")
+		}
+		if length > 1 && built.Hash() == built.Set(0, built.Get(1)).Hash() {
+			t.Error("expected changed list to have a different hash")
+		}
+	}
+}
+
+func TestHashCaching(t *testing.T) {
+	calls := 0
+	hasher := func(value string) uint64 {
+		calls++
+		return hashString(value)
+	}
+	list := FromSlice(createListForTest(1, 10000).Slice(0, 10000), WithHasher(hasher))
+	first := list.Hash()
+	validateSize(t, calls, 10000)
+	if list.Hash() != first {
+		t.Error("expected repeated hash to match")
+	}
+	validateSize(t, calls, 10000)
+
+	calls = 0
+	changed := list.Set(5000, val(0)).Set(5000, val(5001))
+	if changed.Hash() != first {
+		t.Error("expected restored list to have original hash")
+	}
+	if calls > defaultLeafCapacity {
+		t.Error("expected only the copied path to be hashed again")
+	}
+}
+
+func TestHashCachingSharedBetweenLists(t *testing.T) {
+	calls := 0
+	option := WithHasher(func(value string) uint64 {
+		calls++
+		return hashString(value)
+	})
+	a := FromSlice(createListForTest(1, 10000).Slice(0, 10000), option)
+	b := CreateWithOptions[string](option).AppendList(a)
+	a.Hash()
+	b.Hash()
+	a.Hash()
+	validateSize(t, calls, 10000)
+}
+
+func TestHashRequiresHasher(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for list without hasher")
+		}
+	}()
+	createListForTest(1, 10).Hash()
+}
+
+func createListForTestWithHasher(firstValue int, lastValue int) List[string] {
+	builder := CreateBuilder[string](WithHasher(hashString))
+	for i := firstValue; i <= lastValue; i++ {
+		builder.Add(val(i))
+	}
+	return builder.Build()
+}
+
+func hashString(value string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	return h.Sum64()
+}
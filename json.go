@@ -0,0 +1,82 @@
+package immutableList
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MarshalJSON encodes the list as a JSON array of its values.  Since it
+// must return the whole array at once, WriteJSON is better suited to large
+// lists.
+func (this *listImpl[T]) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := this.WriteJSON(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// WriteJSON streams the list to w as a JSON array of its values.  Each value
+// is written as soon as it is encoded so the array is never held in memory.
+func (this *listImpl[T]) WriteJSON(w io.Writer) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	buffer.WriteByte('[')
+	var err error
+	this.root.visit(0, 0, this.Size(), func(index int, value T) bool {
+		if index > 0 {
+			buffer.WriteByte(',')
+		}
+		if err = encoder.Encode(value); err != nil {
+			return true
+		}
+		// Encode ends every value with a newline
+		buffer.Truncate(buffer.Len() - 1)
+		_, err = w.Write(buffer.Bytes())
+		buffer.Reset()
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+	buffer.WriteByte(']')
+	_, err = w.Write(buffer.Bytes())
+	return err
+}
+
+// DecodeJSONList reads a JSON array from r and returns a list of its values.
+// When decoder is nil each value is decoded using encoding/json.  Otherwise
+// decoder receives the raw JSON of each value, which allows values to be
+// decoded into concrete types when T is an interface.
+func DecodeJSONList[T any](r io.Reader, decoder func(json.RawMessage) (T, error), opts ...Option) (List[T], error) {
+	jsonDecoder := json.NewDecoder(r)
+	token, err := jsonDecoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('[') {
+		return nil, fmt.Errorf("expected JSON array but found %v", token)
+	}
+	builder := CreateBuilder[T](opts...)
+	for jsonDecoder.More() {
+		var value T
+		if decoder == nil {
+			err = jsonDecoder.Decode(&value)
+		} else {
+			var raw json.RawMessage
+			if err = jsonDecoder.Decode(&raw); err == nil {
+				value, err = decoder(raw)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		builder.Add(value)
+	}
+	if _, err := jsonDecoder.Token(); err != nil {
+		return nil, err
+	}
+	return builder.Build(), nil
+}
@@ -0,0 +1,105 @@
+package immutableList
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	encoded, err := json.Marshal(struct {
+		Values List[int] `json:"values"`
+	}{FromSlice([]int{1, 2, 3})})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `{"values":[1,2,3]}` {
+		t.Error(fmt.Sprintf("unexpected JSON: %s", encoded))
+	}
+	encoded, _ = json.Marshal(Create[string]())
+	if string(encoded) != `[]` {
+		t.Error(fmt.Sprintf("unexpected JSON: %s", encoded))
+	}
+}
+
+type failingJSONWriter struct {
+	writes int
+}
+
+func (this *failingJSONWriter) Write(p []byte) (int, error) {
+	this.writes++
+	if this.writes > 2 {
+		return 0, errors.New("write failed")
+	}
+	return len(p), nil
+}
+
+func TestWriteJSON(t *testing.T) {
+	for _, length := range []int{0, 1, 33, 1000} {
+		list := createListForTest(1, length)
+		var buffer bytes.Buffer
+		if err := list.WriteJSON(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := json.Marshal(list.Slice(0, length))
+		if buffer.String() != string(expected) {
+			t.Error(fmt.Sprintf("unexpected JSON: %s", buffer.String()))
+		}
+	}
+	writer := &failingJSONWriter{}
+	if err := createListForTest(1, 10).WriteJSON(writer); err == nil {
+		t.Error("expected error from writer")
+	}
+	validateSize(t, writer.writes, 3)
+}
+
+func TestDecodeJSONList(t *testing.T) {
+	for _, length := range []int{0, 1, 33, 1000} {
+		list := createListForTest(1, length)
+		encoded, err := json.Marshal(list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodeJSONList[string](strings.NewReader(string(encoded)), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		validateList(t, decoded, length)
+	}
+}
+
+type jsonShape interface {
+	area() int
+}
+
+type jsonSquare struct {
+	Side int `json:"side"`
+}
+
+func (s jsonSquare) area() int {
+	return s.Side * s.Side
+}
+
+func TestDecodeJSONListWithDecoder(t *testing.T) {
+	decoded, err := DecodeJSONList(strings.NewReader(`[{"side":2},{"side":3}]`), func(raw json.RawMessage) (jsonShape, error) {
+		var square jsonSquare
+		err := json.Unmarshal(raw, &square)
+		return square, err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Size() != 2 || decoded.Get(0).area() != 4 || decoded.Get(1).area() != 9 {
+		t.Error("decoded values do not match")
+	}
+
+	if _, err := DecodeJSONList[int](strings.NewReader(`{"a":1}`), nil); err == nil {
+		t.Error("expected error for JSON object")
+	}
+	if _, err := DecodeJSONList[int](strings.NewReader(`[1,"x"]`), nil); err == nil {
+		t.Error("expected error for mismatched value")
+	}
+}
@@ -1,104 +1,181 @@
 package immutableList
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+)
 
-type Object interface{}
-
-type Processor func(Object)
-type Visitor func(int, Object)
+type Processor[T any] func(T)
 
 type reporter func(message string)
 
-type Iterator interface {
+type Iterator[T any] interface {
 	Next() bool
-	Get() Object
+	Get() T
 }
 
-type List interface {
+type List[T any] interface {
 	Size() int
-	Get(index int) Object
-	GetFirst() Object
-	GetLast() Object
-	Append(value Object) List
-	AppendList(other List) List
-	Insert(indexBefore int, value Object) List
-	InsertList(indexBefore int, other List) List
-	Head(length int) List
-	Tail(index int) List
-	SubList(offset int, limit int) List
-	ForEach(proc Processor)
-	Visit(offset int, limit int, v Visitor)
-	Select(predicate func(Object) bool) List
-	Slice(offset, limit int) []Object
-	Delete(index int) List
-	DeleteRange(offset int, limit int) List
-	Set(index int, value Object) List
-	FwdIterate() Iterator
+	Get(index int) T
+	GetFirst() T
+	GetLast() T
+	Append(value T) List[T]
+	AppendList(other List[T]) List[T]
+	Insert(indexBefore int, value T) List[T]
+	InsertList(indexBefore int, other List[T]) List[T]
+	Head(length int) List[T]
+	Tail(index int) List[T]
+	SubList(offset int, limit int) List[T]
+	ForEach(proc Processor[T])
+	ParallelForEach(workers int, proc Processor[T])
+	Visit(proc VisitProc[T])
+	VisitRange(offset int, limit int, proc VisitProc[T])
+	Select(predicate func(T) bool) List[T]
+	FilterIndexed(predicate func(int, T) bool) List[T]
+	Partition(predicate func(T) bool) (List[T], List[T])
+	Map(mapper func(T) T) List[T]
+	ParallelMap(workers int, mapper func(T) T) List[T]
+	FlatMap(mapper func(T) List[T]) List[T]
+	Sort(less func(a, b T) bool) List[T]
+	SortStable(less func(a, b T) bool) List[T]
+	Search(cmp func(T) int) (int, bool)
+	LowerBound(cmp func(T) int) int
+	UpperBound(cmp func(T) int) int
+	Equal(other List[T], eq func(a, b T) bool) bool
+	Hash() uint64
+	Apply(patch Patch[T]) (List[T], error)
+	MarshalBinary() ([]byte, error)
+	WriteTo(w io.Writer) (int64, error)
+	MarshalJSON() ([]byte, error)
+	WriteJSON(w io.Writer) error
+	String() string
+	Format(f fmt.State, verb rune)
+	LogValue() slog.Value
+	Stats() Stats
+	Slice(offset, limit int) []T
+	Delete(index int) List[T]
+	DeleteRange(offset int, limit int) List[T]
+	Set(index int, value T) List[T]
+	FwdIterate() Iterator[T]
+	RevIterate() Iterator[T]
+	FwdIterateFrom(index int) Iterator[T]
+	RevIterateFrom(index int) Iterator[T]
+	All() iter.Seq2[int, T]
+	Values() iter.Seq[T]
+	Backward() iter.Seq2[int, T]
 	checkInvariants(r reporter)
 
 	IsEmpty() bool
-	Push(value Object) List
-	Pop() (Object, List)
-}
+	Push(value T) List[T]
+	Pop() (T, List[T])
 
-type listImpl struct {
-	root node
+	Transient() Transient[T]
 }
 
-var sharedEmptyListInstance List = &listImpl{sharedEmptyNode}
+type listImpl[T any] struct {
+	root   node[T]
+	config *listConfig[T]
+}
 
-func Create() List {
-	return sharedEmptyListInstance
+func Create[T any]() List[T] {
+	return CreateWithOptions[T]()
 }
 
-func createListNode(root node) List {
+func createListNode[T any](config *listConfig[T], root node[T]) List[T] {
 	if root.size() == 0 {
-		return sharedEmptyListInstance
+		return &listImpl[T]{root: createEmptyLeafNode[T](), config: config}
 	} else {
-		return &listImpl{root: root}
+		return &listImpl[T]{root: root, config: config}
 	}
 }
 
-func (this *listImpl) FwdIterate() Iterator {
+func (this *listImpl[T]) FwdIterate() Iterator[T] {
 	return createIterator(this.root)
 }
 
-func (this *listImpl) Size() int {
+func (this *listImpl[T]) RevIterate() Iterator[T] {
+	return createRevIterator(this.root)
+}
+
+// FwdIterateFrom returns an Iterator whose first value is the one at index.
+// An index equal to Size() produces an exhausted Iterator.
+func (this *listImpl[T]) FwdIterateFrom(index int) Iterator[T] {
+	return createIteratorFrom(this.root, index)
+}
+
+// RevIterateFrom returns an Iterator that walks backwards starting with the value at index.
+// An index of -1 produces an exhausted Iterator.
+func (this *listImpl[T]) RevIterateFrom(index int) Iterator[T] {
+	return createRevIteratorFrom(this.root, index)
+}
+
+func (this *listImpl[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		this.root.all(0, yield)
+	}
+}
+
+func (this *listImpl[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		this.root.all(0, func(_ int, value T) bool {
+			return yield(value)
+		})
+	}
+}
+
+func (this *listImpl[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		this.root.backward(0, yield)
+	}
+}
+
+func (this *listImpl[T]) Size() int {
 	return this.root.size()
 }
 
-func (this *listImpl) Get(index int) Object {
+func (this *listImpl[T]) Get(index int) T {
 	return this.root.get(index)
 }
 
-func (this *listImpl) GetFirst() Object {
+func (this *listImpl[T]) GetFirst() T {
 	return this.root.getFirst()
 }
 
-func (this *listImpl) GetLast() Object {
+func (this *listImpl[T]) GetLast() T {
 	return this.root.getLast()
 }
 
-func (this *listImpl) Append(value Object) List {
-	return createListNode(this.root.append(value))
+func (this *listImpl[T]) Append(value T) List[T] {
+	return createListNode(this.config, this.root.append(value, this.config.leafCapacity))
+}
+
+func (this *listImpl[T]) AppendList(other List[T]) List[T] {
+	return createListNode(this.config, appendNodes(this.root, this.adoptRoot(other), this.config.leafCapacity))
 }
 
-func (this *listImpl) AppendList(other List) List {
-	otherImpl := other.(*listImpl)
-	return createListNode(appendNodes(this.root, otherImpl.root))
+// adoptRoot returns the root of other, rebuilt with this list's leaf capacity
+// if other was created with larger leaves.
+func (this *listImpl[T]) adoptRoot(other List[T]) node[T] {
+	otherImpl := other.(*listImpl[T])
+	if otherImpl.config.leafCapacity <= this.config.leafCapacity {
+		return otherImpl.root
+	}
+	return createNodeFromSlice(other.Slice(0, other.Size()), this.config.leafCapacity)
 }
 
-func (this *listImpl) Insert(indexBefore int, value Object) List {
-	return createListNode(this.root.insert(indexBefore, value))
+func (this *listImpl[T]) Insert(indexBefore int, value T) List[T] {
+	return createListNode(this.config, this.root.insert(indexBefore, value, this.config.leafCapacity))
 }
 
-func (this *listImpl) InsertList(indexBefore int, other List) List {
+func (this *listImpl[T]) InsertList(indexBefore int, other List[T]) List[T] {
 	currentSize := this.root.size()
 	if indexBefore < 0 || indexBefore > currentSize {
 		panic(fmt.Sprintf("index out of bounds: size=%d index=%d", currentSize, indexBefore))
 	}
 	if indexBefore == 0 {
-		return other.AppendList(this)
+		return createListNode(this.config, appendNodes(this.adoptRoot(other), this.root, this.config.leafCapacity))
 	}
 	if indexBefore == currentSize {
 		return this.AppendList(other)
@@ -106,42 +183,43 @@ func (this *listImpl) InsertList(indexBefore int, other List) List {
 	return this.Head(indexBefore).AppendList(other).AppendList(this.Tail(indexBefore))
 }
 
-func (this *listImpl) Delete(index int) List {
-	return createListNode(this.root.delete(index))
+func (this *listImpl[T]) Delete(index int) List[T] {
+	return createListNode(this.config, this.root.delete(index))
 }
 
-func (this *listImpl) DeleteRange(offset int, limit int) List {
+func (this *listImpl[T]) DeleteRange(offset int, limit int) List[T] {
 	size := this.Size()
 	if offset < 0 || limit < offset || limit > size {
 		panic(fmt.Sprintf("invalid offset or limit: size=%d offset=%d limit=%d", size, offset, limit))
 	}
 	if offset == 0 && limit == size {
-		return sharedEmptyListInstance
+		return createListNode(this.config, createEmptyLeafNode[T]())
 	}
 	if offset == limit {
 		return this
 	}
 
-	var root node
+	capacity := this.config.leafCapacity
+	var root node[T]
 	if offset == 0 {
-		root = this.root.tail(limit)
+		root = this.root.tail(limit, capacity)
 	} else if limit == size {
-		root = this.root.head(offset)
+		root = this.root.head(offset, capacity)
 	} else {
-		root = appendNodes(this.root.head(offset), this.root.tail(limit))
+		root = appendNodes(this.root.head(offset, capacity), this.root.tail(limit, capacity), capacity)
 	}
-	return createListNode(root)
+	return createListNode(this.config, root)
 }
 
-func (this *listImpl) Head(length int) List {
-	return createListNode(this.root.head(length))
+func (this *listImpl[T]) Head(length int) List[T] {
+	return createListNode(this.config, this.root.head(length, this.config.leafCapacity))
 }
 
-func (this *listImpl) Tail(index int) List {
-	return createListNode(this.root.tail(index))
+func (this *listImpl[T]) Tail(index int) List[T] {
+	return createListNode(this.config, this.root.tail(index, this.config.leafCapacity))
 }
 
-func (this *listImpl) SubList(offset int, limit int) List {
+func (this *listImpl[T]) SubList(offset int, limit int) List[T] {
 	size := this.Size()
 	if offset < 0 || limit < offset || limit > size {
 		panic(fmt.Sprintf("invalid offset or limit: size=%d offset=%d limit=%d", size, offset, limit))
@@ -150,34 +228,39 @@ func (this *listImpl) SubList(offset int, limit int) List {
 		return this
 	}
 	if offset == limit {
-		return sharedEmptyListInstance
+		return createListNode(this.config, createEmptyLeafNode[T]())
 	}
 
-	var root node
+	capacity := this.config.leafCapacity
+	var root node[T]
 	if offset == 0 {
-		root = this.root.head(limit)
+		root = this.root.head(limit, capacity)
 	} else if limit == size {
-		root = this.root.tail(offset)
+		root = this.root.tail(offset, capacity)
 	} else {
-		root = this.root.head(limit).tail(offset)
+		root = this.root.head(limit, capacity).tail(offset, capacity)
 	}
-	return createListNode(root)
+	return createListNode(this.config, root)
 }
 
-func (this *listImpl) ForEach(proc Processor) {
+func (this *listImpl[T]) ForEach(proc Processor[T]) {
 	this.root.forEach(proc)
 }
 
-func (this *listImpl) Visit(offset int, limit int, visitor Visitor) {
+func (this *listImpl[T]) Visit(proc VisitProc[T]) {
+	this.root.visit(0, 0, this.Size(), proc)
+}
+
+func (this *listImpl[T]) VisitRange(offset int, limit int, proc VisitProc[T]) {
 	if offset < 0 || limit < offset || limit > this.Size() {
 		panic(fmt.Sprintf("invalid offset or limit: size=%d offset=%d limit=%d", this.Size(), offset, limit))
 	}
-	this.root.visit(0, offset, limit, visitor)
+	this.root.visit(0, offset, limit, proc)
 }
 
-func (this *listImpl) Select(predicate func(Object) bool) List {
-	answer := CreateBuilder()
-	this.root.forEach(func(obj Object) {
+func (this *listImpl[T]) Select(predicate func(T) bool) List[T] {
+	answer := createBuilder(this.config)
+	this.root.forEach(func(obj T) {
 		if predicate(obj) {
 			answer.Add(obj)
 		}
@@ -185,52 +268,94 @@ func (this *listImpl) Select(predicate func(Object) bool) List {
 	return answer.Build()
 }
 
-func (this *listImpl) Slice(offset, limit int) []Object {
+func (this *listImpl[T]) FilterIndexed(predicate func(int, T) bool) List[T] {
+	answer := createBuilder(this.config)
+	this.root.visit(0, 0, this.Size(), func(index int, obj T) bool {
+		if predicate(index, obj) {
+			answer.Add(obj)
+		}
+		return false
+	})
+	return answer.Build()
+}
+
+// Partition returns the values matching predicate followed by those that do not.
+func (this *listImpl[T]) Partition(predicate func(T) bool) (List[T], List[T]) {
+	matched := createBuilder(this.config)
+	unmatched := createBuilder(this.config)
+	this.root.forEach(func(obj T) {
+		if predicate(obj) {
+			matched.Add(obj)
+		} else {
+			unmatched.Add(obj)
+		}
+	})
+	return matched.Build(), unmatched.Build()
+}
+
+// Map replaces every value with the result of mapper.  Each leaf maps to a
+// leaf of the same size so the result has the same shape as this list.
+func (this *listImpl[T]) Map(mapper func(T) T) List[T] {
+	return createListNode(this.config, this.root.mapValues(mapper))
+}
+
+func (this *listImpl[T]) FlatMap(mapper func(T) List[T]) List[T] {
+	answer := createBuilder(this.config)
+	this.root.forEach(func(obj T) {
+		mapper(obj).ForEach(func(mapped T) {
+			answer.Add(mapped)
+		})
+	})
+	return answer.Build()
+}
+
+func (this *listImpl[T]) Slice(offset, limit int) []T {
 	if offset < 0 || limit < offset || limit > this.Size() {
 		panic(fmt.Sprintf("invalid offset or limit: size=%d offset=%d limit=%d", this.Size(), offset, limit))
 	}
 	if limit == offset {
-		return make([]Object, 0)
+		return make([]T, 0)
 	}
-	answer := make([]Object, limit-offset)
-	this.root.visit(0, offset, limit, func(index int, obj Object) {
+	answer := make([]T, limit-offset)
+	this.root.visit(0, offset, limit, func(index int, obj T) bool {
 		answer[index-offset] = obj
+		return false
 	})
 	return answer
 }
 
-func (this *listImpl) Set(index int, value Object) List {
+func (this *listImpl[T]) Set(index int, value T) List[T] {
 	if index == this.root.size() {
-		return createListNode(this.root.append(value))
+		return createListNode(this.config, this.root.append(value, this.config.leafCapacity))
 	} else {
-		return createListNode(this.root.set(index, value))
+		return createListNode(this.config, this.root.set(index, value))
 	}
 }
 
-func (this *listImpl) checkInvariants(report reporter) {
-	if this.Size() == 0 && this != sharedEmptyListInstance {
-		report("empty list is not the sharedEmptyListInstance")
+func (this *listImpl[T]) checkInvariants(report reporter) {
+	if _, isEmpty := this.root.(*emptyNode[T]); this.Size() == 0 && !isEmpty {
+		report("empty list does not have an emptyNode root")
 	}
-	this.root.checkInvariants(report, true)
+	this.root.checkInvariants(report, true, this.config.leafCapacity)
 }
 
-func (this *listImpl) IsEmpty() bool {
+func (this *listImpl[T]) IsEmpty() bool {
 	return this.root.size() == 0
 }
 
-func (this *listImpl) Push(value Object) List {
-	return createListNode(this.root.prepend(value))
+func (this *listImpl[T]) Push(value T) List[T] {
+	return createListNode(this.config, this.root.prepend(value, this.config.leafCapacity))
 }
 
-func (this *listImpl) Pop() (Object, List) {
+func (this *listImpl[T]) Pop() (T, List[T]) {
 	switch this.Size() {
 	case 0:
 		panic("Pop called on empty List")
 	case 1:
 		value := this.root.getFirst()
-		return value, sharedEmptyListInstance
+		return value, createListNode(this.config, createEmptyLeafNode[T]())
 	default:
 		value, newRoot := this.root.pop()
-		return value, createListNode(newRoot)
+		return value, createListNode(this.config, newRoot)
 	}
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func TestAppend(t *testing.T) {
-	list := Create()
+	list := Create[string]()
 	list = list.Append(val(1)).Append(val(2)).Append(val(3))
 	validateList(t, list, 3)
 
@@ -18,7 +18,7 @@ func TestAppend(t *testing.T) {
 }
 
 func TestInsert(t *testing.T) {
-	list := Create()
+	list := Create[string]()
 	list = list.Insert(0, val(1)).Insert(1, val(512))
 
 	for i := 2; i <= 256; i++ {
@@ -30,8 +30,8 @@ func TestInsert(t *testing.T) {
 }
 
 func TestInsert2(t *testing.T) {
-	list := Create()
-	expected := make([]Object, 0)
+	list := Create[string]()
+	expected := make([]string, 0)
 	nextValue := 1
 	for list.Size() < 4096 {
 		size := list.Size()
@@ -54,25 +54,69 @@ func TestInsert2(t *testing.T) {
 }
 
 func TestSelect(t *testing.T) {
-	list := Create()
+	list := Create[string]()
 	for i := 1; i <= 1024; i++ {
 		list = list.Append(val(i))
 	}
-	list = list.Select(func(obj Object) bool {
-		i, _ := strconv.ParseInt(obj.(string), 10, 64)
+	list = list.Select(func(obj string) bool {
+		i, _ := strconv.ParseInt(obj, 10, 64)
 		return i <= 512
 	})
 	validateList(t, list, 512)
 }
 
+func TestFilterIndexed(t *testing.T) {
+	list := createListForTest(1, 1024)
+	list = list.FilterIndexed(func(index int, obj string) bool {
+		return index < 700 && obj != val(1024)
+	})
+	validateList(t, list, 700)
+}
+
+func TestPartition(t *testing.T) {
+	list := createListForTest(1, 1024)
+	low, high := list.Partition(func(obj string) bool {
+		i, _ := strconv.Atoi(obj)
+		return i <= 300
+	})
+	validateList(t, low, 300)
+	validateList2(t, high, 301, 1024)
+}
+
+func TestMap(t *testing.T) {
+	for _, length := range []int{0, 1, 32, 33, 500, 1024} {
+		list := createListForTestDirectly(1, length)
+		mapped := list.Map(func(obj string) string {
+			i, _ := strconv.Atoi(obj)
+			return val(i + 1000)
+		})
+		validateList2(t, mapped.Map(func(obj string) string {
+			i, _ := strconv.Atoi(obj)
+			return val(i - 1000)
+		}), 1, length)
+		if mapped.Size() > 0 && mapped.(*listImpl[string]).root.depth() != list.(*listImpl[string]).root.depth() {
+			t.Error("expected Map to preserve tree depth")
+		}
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	list := createListForTest(1, 100)
+	flat := list.FlatMap(func(obj string) List[string] {
+		i, _ := strconv.Atoi(obj)
+		return createListForTest(2*i-1, 2*i)
+	})
+	validateList(t, flat, 200)
+}
+
 func TestSlice(t *testing.T) {
-	list := Create()
+	list := Create[string]()
 	for i := 1; i <= 400; i++ {
 		list = list.Append(val(i))
 	}
 	sliced := list.Slice(0, 123)
 	for i, v := range sliced {
-		if val(i+1) != v.(string) {
+		if val(i+1) != v {
 			t.Error(fmt.Sprintf("slice expected %v/%s but got %v/%s", i, val(i+1), i, v))
 		}
 	}
@@ -112,7 +156,7 @@ func TestHead(t *testing.T) {
 		truncated := list.Head(i)
 		validateList(t, truncated, i)
 	}
-	list = createListForTest(1, maxValuesPerLeaf)
+	list = createListForTest(1, defaultLeafCapacity)
 	for i := list.Size(); i >= 0; i-- {
 		list = list.Head(i)
 		validateList(t, list, i)
@@ -125,10 +169,10 @@ func TestTail(t *testing.T) {
 		truncated := list.Tail(i)
 		validateList2(t, truncated, i+1, list.Size())
 	}
-	list = createListForTest(1, maxValuesPerLeaf)
-	for i := 1; i <= maxValuesPerLeaf; i++ {
+	list = createListForTest(1, defaultLeafCapacity)
+	for i := 1; i <= defaultLeafCapacity; i++ {
 		list = list.Tail(1)
-		validateList2(t, list, i+1, maxValuesPerLeaf)
+		validateList2(t, list, i+1, defaultLeafCapacity)
 	}
 }
 
@@ -186,14 +230,14 @@ func TestDeleteRange(t *testing.T) {
 }
 
 func TestBuilder(t *testing.T) {
-	builder := CreateBuilder()
+	builder := CreateBuilder[string]()
 	validateList(t, builder.Build(), 0)
-	for i := 1; i <= maxValuesPerLeaf; i++ {
+	for i := 1; i <= defaultLeafCapacity; i++ {
 		builder.Add(val(i))
 		validateSize(t, builder.Size(), i)
 		validateList(t, builder.Build(), i)
 	}
-	for i := maxValuesPerLeaf + 1; i <= 200; i++ {
+	for i := defaultLeafCapacity + 1; i <= 200; i++ {
 		builder.Add(val(i))
 		validateSize(t, builder.Size(), i)
 		validateList(t, builder.Build(), i)
@@ -212,6 +256,34 @@ func TestBuilder(t *testing.T) {
 	validateList(t, builder.Build(), 700)
 }
 
+func TestFromSlice(t *testing.T) {
+	for length := 0; length <= 2100; length += 7 {
+		values := make([]string, length)
+		for i := range values {
+			values[i] = val(i + 1)
+		}
+		fromSlice := FromSlice(values)
+		validateList(t, fromSlice, length)
+		validateMinimumDepth(t, fromSlice, length)
+
+		fromIterator := FromIterator(fromSlice.FwdIterate())
+		validateList(t, fromIterator, length)
+		validateMinimumDepth(t, fromIterator, length)
+	}
+}
+
+func validateMinimumDepth(t *testing.T, list List[string], length int) {
+	leafCount := (length + defaultLeafCapacity - 1) / defaultLeafCapacity
+	expected := 0
+	for (1 << expected) < leafCount {
+		expected++
+	}
+	actual := list.(*listImpl[string]).root.depth()
+	if actual != expected {
+		t.Error(fmt.Sprintf("expected depth %d but got %d", expected, actual))
+	}
+}
+
 func TestAppendList(t *testing.T) {
 	for totalSize := 1; totalSize <= 100; totalSize++ {
 		for firstSize := 0; firstSize <= totalSize; firstSize++ {
@@ -222,7 +294,7 @@ func TestAppendList(t *testing.T) {
 		}
 	}
 
-	var merged List
+	var merged List[string]
 	merged = createListForTest(1, 1000).AppendList(createListForTest(1001, 2000))
 	validateList(t, merged, 2000)
 	merged = createListForTest(1, 1000).AppendList(createListForTest(1001, 20000))
@@ -262,23 +334,133 @@ func TestIterator(t *testing.T) {
 	}
 }
 
+func TestRevIterator(t *testing.T) {
+	for length := 0; length <= 1024; length++ {
+		list := createListForTest(1, length)
+		actual := Create[string]()
+		for i := list.RevIterate(); i.Next(); {
+			actual = actual.Push(i.Get())
+		}
+		validateList(t, actual, length)
+	}
+}
+
+func TestIterateFrom(t *testing.T) {
+	for _, length := range []int{0, 1, 31, 32, 33, 100, 1024, 1500} {
+		list := createListForTest(1, length)
+		for start := 0; start <= length; start++ {
+			actual := Create[string]()
+			for i := list.FwdIterateFrom(start); i.Next(); {
+				actual = actual.Append(i.Get())
+			}
+			validateList2(t, actual, start+1, length)
+		}
+		for start := -1; start < length; start++ {
+			actual := Create[string]()
+			for i := list.RevIterateFrom(start); i.Next(); {
+				actual = actual.Push(i.Get())
+			}
+			validateList(t, actual, start+1)
+		}
+	}
+}
+
+func TestRangeIterators(t *testing.T) {
+	for length := 0; length <= 300; length++ {
+		list := createListForTest(1, length)
+		expectedIndex := 0
+		for i, v := range list.All() {
+			if i != expectedIndex || v != val(i+1) {
+				t.Error(fmt.Sprintf("All expected %v/%s but got %v/%s", expectedIndex, val(expectedIndex+1), i, v))
+			}
+			expectedIndex++
+		}
+		validateSize(t, expectedIndex, length)
+
+		expectedIndex = 0
+		for v := range list.Values() {
+			if v != val(expectedIndex+1) {
+				t.Error(fmt.Sprintf("Values expected %s but got %s", val(expectedIndex+1), v))
+			}
+			expectedIndex++
+		}
+		validateSize(t, expectedIndex, length)
+
+		expectedIndex = length - 1
+		for i, v := range list.Backward() {
+			if i != expectedIndex || v != val(i+1) {
+				t.Error(fmt.Sprintf("Backward expected %v/%s but got %v/%s", expectedIndex, val(expectedIndex+1), i, v))
+			}
+			expectedIndex--
+		}
+		validateSize(t, expectedIndex, -1)
+	}
+}
+
+func TestRangeIteratorsBreak(t *testing.T) {
+	list := createListForTest(1, 1000)
+	count := 0
+	for i := range list.All() {
+		count++
+		if i == 499 {
+			break
+		}
+	}
+	validateSize(t, count, 500)
+
+	count = 0
+	for i := range list.Backward() {
+		count++
+		if i == 500 {
+			break
+		}
+	}
+	validateSize(t, count, 500)
+}
+
+func TestVisitEarlyExit(t *testing.T) {
+	list := createListForTest(1, 1000)
+	count := 0
+	list.Visit(func(index int, obj string) bool {
+		count++
+		return obj == val(600)
+	})
+	validateSize(t, count, 600)
+
+	count = 0
+	list.VisitRange(100, 900, func(index int, obj string) bool {
+		count++
+		return index == 199
+	})
+	validateSize(t, count, 100)
+}
+
+func TestReduce(t *testing.T) {
+	var source Visitable[string] = createListForTest(1, 1000)
+	total := Reduce(source, 0, func(acc int, value string) int {
+		i, _ := strconv.Atoi(value)
+		return acc + i
+	})
+	validateSize(t, total, 500500)
+}
+
 func TestStackOps(t *testing.T) {
-	stack := Create().Push(val(4)).Push(val(3)).Push(val(2)).Push(val(1))
-	popped := Create()
+	stack := Create[string]().Push(val(4)).Push(val(3)).Push(val(2)).Push(val(1))
+	popped := Create[string]()
 	for !stack.IsEmpty() {
-		var value Object
+		var value string
 		value, stack = stack.Pop()
 		popped = popped.Append(value)
 	}
 	validateList(t, popped, 4)
 
-	stack = Create()
+	stack = Create[string]()
 	for i := 500; i >= 1; i-- {
 		stack = stack.Push(val(i))
 	}
-	popped = Create()
+	popped = Create[string]()
 	for !stack.IsEmpty() {
-		var value Object
+		var value string
 		value, stack = stack.Pop()
 		popped = popped.Append(value)
 	}
@@ -286,10 +468,10 @@ func TestStackOps(t *testing.T) {
 }
 
 func TestQueueOps(t *testing.T) {
-	s := Create().Append(val(1)).Append(val(2)).Append(val(3)).Append(val(4))
-	popped := Create()
+	s := Create[string]().Append(val(1)).Append(val(2)).Append(val(3)).Append(val(4))
+	popped := Create[string]()
 	for !s.IsEmpty() {
-		var value Object
+		var value string
 		value, s = s.Pop()
 		popped = popped.Append(value)
 	}
@@ -311,7 +493,7 @@ func TestFirstLast(t *testing.T) {
 }
 
 func TestDeleteAll(t *testing.T) {
-	for length := 16; length <= 512; length += maxValuesPerLeaf {
+	for length := 16; length <= 512; length += defaultLeafCapacity {
 		increment := length / 11
 		for index := 0; index < length; index += increment {
 			if index < length {
@@ -323,8 +505,8 @@ func TestDeleteAll(t *testing.T) {
 }
 
 func deleteAllImpl(t *testing.T, length int, index int) {
-	b := CreateBuilder()
-	expected := make([]Object, length)
+	b := CreateBuilder[string]()
+	expected := make([]string, length)
 	for i := 0; i < length; i++ {
 		value := val(i)
 		b.Add(value)
@@ -346,13 +528,13 @@ func TestPopAll(t *testing.T) {
 	length := 1
 	for length <= 4096 {
 		popAllImpl(t, length)
-		length = length * maxValuesPerLeaf
+		length = length * defaultLeafCapacity
 	}
 }
 
 func popAllImpl(t *testing.T, length int) {
-	b := CreateBuilder()
-	expected := make([]Object, length)
+	b := CreateBuilder[string]()
+	expected := make([]string, length)
 	for i := 0; i < length; i++ {
 		value := val(i)
 		b.Add(value)
@@ -362,7 +544,7 @@ func popAllImpl(t *testing.T, length int) {
 	validateList3(t, actual, expected)
 	expectedValue := 0
 	for len(expected) > 0 {
-		var deletedValue Object
+		var deletedValue string
 		deletedValue, actual = actual.Pop()
 		expected = deleteFromSlice(expected, 0)
 		validateValue(t, expectedValue, deletedValue)
@@ -371,8 +553,8 @@ func popAllImpl(t *testing.T, length int) {
 	}
 }
 
-func copyList(list List) List {
-	answer := Create()
+func copyList(list List[string]) List[string] {
+	answer := Create[string]()
 	for i := list.FwdIterate(); i.Next(); {
 		answer = answer.Append(i.Get())
 	}
@@ -406,10 +588,10 @@ func TestInsertList(t *testing.T) {
 	validateInsertList(t, inserted, 300, 500, 0)
 
 	prefix = createListForTestInsertList(val(1), 3)
-	suffix := createListForTestInsertList(val(3), maxValuesPerLeaf-1)
-	middle = createListForTestInsertList(val(2), maxValuesPerLeaf-1)
+	suffix := createListForTestInsertList(val(3), defaultLeafCapacity-1)
+	middle = createListForTestInsertList(val(2), defaultLeafCapacity-1)
 	inserted = prefix.AppendList(suffix).InsertList(3, middle)
-	validateInsertList(t, inserted, 3, maxValuesPerLeaf-1, maxValuesPerLeaf-1)
+	validateInsertList(t, inserted, 3, defaultLeafCapacity-1, defaultLeafCapacity-1)
 
 	prefix = createListForTestInsertList(val(1), 300)
 	suffix = createListForTestInsertList(val(3), 300)
@@ -418,60 +600,61 @@ func TestInsertList(t *testing.T) {
 	validateInsertList(t, inserted, 300, 500, 300)
 }
 
-func createListForTest(firstValue int, lastValue int) List {
-	builder := CreateBuilder()
+func createListForTest(firstValue int, lastValue int) List[string] {
+	builder := CreateBuilder[string]()
 	for i := firstValue; i <= lastValue; i++ {
 		builder.Add(val(i))
 	}
 	return builder.Build()
 }
 
-func createListForTestDirectly(firstValue int, lastValue int) List {
-	answer := Create()
+func createListForTestDirectly(firstValue int, lastValue int) List[string] {
+	answer := Create[string]()
 	for i := firstValue; i <= lastValue; i++ {
 		answer = answer.Append(val(i))
 	}
 	return answer
 }
 
-func createListForTestInsertList(value Object, length int) List {
-	answer := CreateBuilder()
+func createListForTestInsertList(value string, length int) List[string] {
+	answer := CreateBuilder[string]()
 	for i := 1; i <= length; i++ {
 		answer.Add(value)
 	}
 	return answer.Build()
 }
 
-func createListForTestReverseDirectly(firstValue int, lastValue int) List {
-	answer := Create()
+func createListForTestReverseDirectly(firstValue int, lastValue int) List[string] {
+	answer := Create[string]()
 	for i := lastValue; i >= firstValue; i-- {
 		answer = answer.Insert(0, val(i))
 	}
 	return answer
 }
 
-func validateValue(t *testing.T, expected int, actual Object) {
+func validateValue(t *testing.T, expected int, actual string) {
 	e := val(expected)
 	if actual != e {
 		t.Error(fmt.Sprintf("expected %v but got %v", expected, actual))
 	}
 }
 
-func validateList(t *testing.T, list List, size int) {
+func validateList(t *testing.T, list List[string], size int) {
 	validateList2(t, list, 1, size)
 }
 
-func validateList2(t *testing.T, list List, first int, last int) {
+func validateList2(t *testing.T, list List[string], first int, last int) {
 	size := last - first + 1
 	if list.Size() != size {
 		t.Error(fmt.Sprintf("expected size %d but got %v", size, list.Size()))
 	}
 	ei := 0
-	list.Visit(0, list.Size(), func(index int, obj Object) {
-		if index != ei || obj.(string) != val(index+first) {
+	list.VisitRange(0, list.Size(), func(index int, obj string) bool {
+		if index != ei || obj != val(index+first) {
 			t.Error(fmt.Sprintf("visitor expected %v/%s but got %v/%s", ei, val(ei+1), index, obj))
 		}
 		ei += 1
+		return false
 	})
 	if ei != list.Size() {
 		t.Error(fmt.Sprintf("expected count %d but got %v", list.Size(), ei))
@@ -488,17 +671,18 @@ func validateList2(t *testing.T, list List, first int, last int) {
 	})
 }
 
-func validateList3(t *testing.T, list List, expected []Object) {
+func validateList3(t *testing.T, list List[string], expected []string) {
 	size := len(expected)
 	if list.Size() != size {
 		t.Error(fmt.Sprintf("expected size %d but got %v", size, list.Size()))
 	}
 	ei := 0
-	list.Visit(0, list.Size(), func(index int, obj Object) {
-		if index != ei || obj.(string) != expected[index] {
+	list.VisitRange(0, list.Size(), func(index int, obj string) bool {
+		if index != ei || obj != expected[index] {
 			t.Error(fmt.Sprintf("visitor expected %v/%s but got %v/%s", ei, val(ei+1), index, obj))
 		}
 		ei += 1
+		return false
 	})
 	if ei != list.Size() {
 		t.Error(fmt.Sprintf("expected count %d but got %v", list.Size(), ei))
@@ -514,7 +698,7 @@ func validateList3(t *testing.T, list List, expected []Object) {
 	})
 }
 
-func validateInsertList(t *testing.T, list List, prefixLength int, insertLength int, suffixLength int) {
+func validateInsertList(t *testing.T, list List[string], prefixLength int, insertLength int, suffixLength int) {
 	size := prefixLength + insertLength + suffixLength
 	if list.Size() != size {
 		t.Error(fmt.Sprintf("expected size %d but got %v", size, list.Size()))
@@ -541,7 +725,7 @@ func val(index int) string {
 	return fmt.Sprintf("%v", index)
 }
 
-func validateRegion(t *testing.T, expected Object, list List, offset int, limit int) {
+func validateRegion(t *testing.T, expected string, list List[string], offset int, limit int) {
 	failed := false
 	for i := offset; i < limit; i++ {
 		actual := list.Get(i)
@@ -554,16 +738,16 @@ func validateRegion(t *testing.T, expected Object, list List, offset int, limit
 	}
 }
 
-func insertToSlice(slice []Object, index int, value string) []Object {
-	answer := make([]Object, len(slice)+1)
+func insertToSlice(slice []string, index int, value string) []string {
+	answer := make([]string, len(slice)+1)
 	copy(answer[0:], slice[0:index])
 	answer[index] = value
 	copy(answer[index+1:], slice[index:])
 	return answer
 }
 
-func deleteFromSlice(slice []Object, index int) []Object {
-	answer := make([]Object, len(slice)-1)
+func deleteFromSlice(slice []string, index int) []string {
+	answer := make([]string, len(slice)-1)
 	copy(answer[0:], slice[0:index])
 	copy(answer[index:], slice[index+1:])
 	return answer
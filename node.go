@@ -1,55 +1,83 @@
 package immutableList
 
-import "fmt"
+import (
+	"fmt"
+	"sync/atomic"
+)
 
-type node interface {
+type node[T any] interface {
 	size() int
-	get(index int) Object
-	getFirst() Object
-	getLast() Object
-	append(value Object) node
-	prepend(value Object) node
-	appendNode(n node) node
-	prependNode(n node) node
-	insert(index int, value Object) node
-	delete(index int) node
-	set(index int, value Object) node
-	head(index int) node
-	tail(index int) node
-	pop() (Object, node)
+	get(index int) T
+	getFirst() T
+	getLast() T
+	append(value T, capacity int) node[T]
+	prepend(value T, capacity int) node[T]
+	appendNode(n node[T], capacity int) node[T]
+	prependNode(n node[T], capacity int) node[T]
+	insert(index int, value T, capacity int) node[T]
+	delete(index int) node[T]
+	set(index int, value T) node[T]
+	head(index int, capacity int) node[T]
+	tail(index int, capacity int) node[T]
+	pop() (T, node[T])
 	depth() int
-	forEach(proc Processor)
-	visit(base int, start int, limit int, v Visitor)
-	checkInvariants(report reporter, isRoot bool)
-	rotateLeft(parentLeft node) node
-	rotateRight(parentRight node) node
-	next(state *iteratorState) (*iteratorState, Object)
-	left() node
-	right() node
-}
-
-type iteratorState struct {
-	next         *iteratorState
-	currentNode  node
+	forEach(proc Processor[T])
+	mapValues(mapper func(T) T) node[T]
+	sort(less func(a, b T) bool, stable bool, capacity int) node[T]
+	search(pred func(T) bool) int
+	contentHash(config *listConfig[T]) *nodeHash[T]
+	visit(base int, start int, limit int, v VisitProc[T]) bool
+	all(base int, yield func(int, T) bool) bool
+	backward(base int, yield func(int, T) bool) bool
+	checkInvariants(report reporter, isRoot bool, capacity int)
+	rotateLeft(parentLeft node[T]) node[T]
+	rotateRight(parentRight node[T]) node[T]
+	next(state *iteratorState[T]) (*iteratorState[T], T)
+	prev(state *iteratorState[T]) (*iteratorState[T], T)
+	seek(state *iteratorState[T], index int) *iteratorState[T]
+	seekRev(state *iteratorState[T], index int) *iteratorState[T]
+	ownedSet(owner *transientOwner, index int, value T) node[T]
+	ownedAppend(owner *transientOwner, value T, capacity int) node[T]
+	ownedInsert(owner *transientOwner, index int, value T, capacity int) node[T]
+	ownedDelete(owner *transientOwner, index int) node[T]
+	left() node[T]
+	right() node[T]
+}
+
+type iteratorState[T any] struct {
+	next         *iteratorState[T]
+	currentNode  node[T]
 	currentIndex int
 }
 
-type iteratorImpl struct {
-	state *iteratorState
-	value Object
+type iteratorImpl[T any] struct {
+	state *iteratorState[T]
+	value T
 }
 
-func createIterator(n node) Iterator {
-	var state *iteratorState
+func createIterator[T any](n node[T]) Iterator[T] {
+	var state *iteratorState[T]
 	if n.size() == 0 {
 		state = nil
 	} else {
-		state = &iteratorState{currentNode: n}
+		state = &iteratorState[T]{currentNode: n}
 	}
-	return &iteratorImpl{state: state}
+	return &iteratorImpl[T]{state: state}
 }
 
-func (this *iteratorImpl) Next() bool {
+func createIteratorFrom[T any](n node[T], index int) Iterator[T] {
+	var state *iteratorState[T]
+	if index < 0 || index > n.size() {
+		panic(fmt.Sprintf("index out of bounds: size=%d index=%d", n.size(), index))
+	} else if index == n.size() {
+		state = nil
+	} else {
+		state = n.seek(nil, index)
+	}
+	return &iteratorImpl[T]{state: state}
+}
+
+func (this *iteratorImpl[T]) Next() bool {
 	if this.state == nil {
 		return false
 	}
@@ -57,91 +85,133 @@ func (this *iteratorImpl) Next() bool {
 	return true
 }
 
-func (this *iteratorImpl) Get() Object {
+func (this *iteratorImpl[T]) Get() T {
 	return this.value
 }
 
+type revIteratorImpl[T any] struct {
+	state *iteratorState[T]
+	value T
+}
+
+func createRevIterator[T any](n node[T]) Iterator[T] {
+	var state *iteratorState[T]
+	if n.size() == 0 {
+		state = nil
+	} else {
+		state = &iteratorState[T]{currentNode: n}
+	}
+	return &revIteratorImpl[T]{state: state}
+}
+
+func createRevIteratorFrom[T any](n node[T], index int) Iterator[T] {
+	var state *iteratorState[T]
+	if index < -1 || index >= n.size() {
+		panic(fmt.Sprintf("index out of bounds: size=%d index=%d", n.size(), index))
+	} else if index == -1 {
+		state = nil
+	} else {
+		state = n.seekRev(nil, index)
+	}
+	return &revIteratorImpl[T]{state: state}
+}
+
+func (this *revIteratorImpl[T]) Next() bool {
+	if this.state == nil {
+		return false
+	}
+	this.state, this.value = this.state.currentNode.prev(this.state)
+	return true
+}
+
+func (this *revIteratorImpl[T]) Get() T {
+	return this.value
+}
+
+// Lists created without WithLeafCapacity hold up to this many values per leaf.
 const (
-	maxValuesPerLeaf = 32
+	defaultLeafCapacity = 32
 )
 
-type leafNode struct {
-	values []Object
+type leafNode[T any] struct {
+	values []T
+	owner  *transientOwner // nil unless created by a transient
+	hash   atomic.Pointer[nodeHash[T]]
 }
 
-func createSingleValueLeafNode(value Object) node {
-	values := make([]Object, 1)
+func createSingleValueLeafNode[T any](value T) node[T] {
+	values := make([]T, 1)
 	values[0] = value
 	return createMultiValueLeafNode(values)
 }
 
-func createMultiValueLeafNode(values []Object) node {
-	return &leafNode{values: values}
+func createMultiValueLeafNode[T any](values []T) node[T] {
+	return &leafNode[T]{values: values}
 }
 
-func (a *leafNode) get(index int) Object {
+func (a *leafNode[T]) get(index int) T {
 	return a.values[index]
 }
 
-func (a *leafNode) getFirst() Object {
+func (a *leafNode[T]) getFirst() T {
 	return a.values[0]
 }
 
-func (a *leafNode) getLast() Object {
+func (a *leafNode[T]) getLast() T {
 	return a.values[len(a.values)-1]
 }
 
-func (a *leafNode) pop() (Object, node) {
+func (a *leafNode[T]) pop() (T, node[T]) {
 	return a.values[0], a.delete(0)
 }
 
-func (a *leafNode) set(index int, value Object) node {
+func (a *leafNode[T]) set(index int, value T) node[T] {
 	currentSize := len(a.values)
 	if index < 0 || index >= currentSize {
 		panic(fmt.Sprintf("invalid index for leaf node: %d", index))
 	}
-	newValues := make([]Object, currentSize)
+	newValues := make([]T, currentSize)
 	copy(newValues, a.values)
 	newValues[index] = value
 	return createMultiValueLeafNode(newValues)
 }
 
-func (a *leafNode) insert(index int, value Object) node {
+func (a *leafNode[T]) insert(index int, value T, capacity int) node[T] {
 	currentSize := len(a.values)
 	if index < 0 || index > currentSize {
 		panic(fmt.Sprintf("invalid index for leaf node: %d", index))
 	}
 
 	if index == 0 {
-		return a.prepend(value)
+		return a.prepend(value, capacity)
 	} else if index == currentSize {
-		return a.append(value)
-	} else if currentSize < maxValuesPerLeaf {
-		values := make([]Object, currentSize+1)
+		return a.append(value, capacity)
+	} else if currentSize < capacity {
+		values := make([]T, currentSize+1)
 		copy(values[0:], a.values[0:index])
 		values[index] = value
 		copy(values[(index+1):], a.values[index:])
 		return createMultiValueLeafNode(values)
 	} else {
-		left := make([]Object, index)
+		left := make([]T, index)
 		copy(left[0:], a.values[0:index])
 
-		right := make([]Object, currentSize+1-index)
+		right := make([]T, currentSize+1-index)
 		right[0] = value
 		copy(right[1:], a.values[index:])
 		return createBranchNode(createMultiValueLeafNode(left), createMultiValueLeafNode(right))
 	}
 }
 
-func (a *leafNode) delete(index int) node {
+func (a *leafNode[T]) delete(index int) node[T] {
 	currentSize := len(a.values)
 	if index < 0 || index >= currentSize {
 		panic(fmt.Sprintf("invalid index for leaf node: %d", index))
 	}
 	if len(a.values) == 1 {
-		return createEmptyLeafNode()
+		return createEmptyLeafNode[T]()
 	}
-	values := make([]Object, currentSize-1)
+	values := make([]T, currentSize-1)
 	if index == 0 {
 		copy(values[0:], a.values[1:])
 	} else if index == currentSize-1 {
@@ -153,67 +223,96 @@ func (a *leafNode) delete(index int) node {
 	return createMultiValueLeafNode(values)
 }
 
-func (a *leafNode) append(value Object) node {
+func (a *leafNode[T]) append(value T, capacity int) node[T] {
 	currentSize := len(a.values)
-	if currentSize < maxValuesPerLeaf {
-		values := make([]Object, currentSize+1)
+	if currentSize < capacity {
+		values := make([]T, currentSize+1)
 		copy(values[0:], a.values[0:])
 		values[currentSize] = value
 		return createMultiValueLeafNode(values)
 	} else {
-		values := make([]Object, 1)
+		values := make([]T, 1)
 		values[0] = value
-		return createBranchNode(a, createMultiValueLeafNode(values))
+		return createBranchNode[T](a, createMultiValueLeafNode(values))
 	}
 }
 
-func (a *leafNode) prepend(value Object) node {
+func (a *leafNode[T]) prepend(value T, capacity int) node[T] {
 	currentSize := len(a.values)
-	if currentSize < maxValuesPerLeaf {
-		values := make([]Object, currentSize+1)
+	if currentSize < capacity {
+		values := make([]T, currentSize+1)
 		values[0] = value
 		copy(values[1:], a.values[0:])
 		return createMultiValueLeafNode(values)
 	} else {
-		values := make([]Object, 1)
+		values := make([]T, 1)
 		values[0] = value
-		return createBranchNode(createMultiValueLeafNode(values), a)
+		return createBranchNode[T](createMultiValueLeafNode(values), a)
 	}
 }
 
-func (a *leafNode) forEach(proc Processor) {
+func (a *leafNode[T]) forEach(proc Processor[T]) {
 	for _, value := range a.values {
 		proc(value)
 	}
 }
 
-func (a *leafNode) visit(base int, start int, limit int, v Visitor) {
+func (a *leafNode[T]) mapValues(mapper func(T) T) node[T] {
+	values := make([]T, len(a.values))
+	for i, value := range a.values {
+		values[i] = mapper(value)
+	}
+	return createMultiValueLeafNode(values)
+}
+
+func (a *leafNode[T]) visit(base int, start int, limit int, v VisitProc[T]) bool {
 	size := len(a.values)
 	if limit > size {
 		limit = size
 	}
 	for i := start; i < limit; i++ {
-		v(base+i, a.values[i])
+		if v(base+i, a.values[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *leafNode[T]) all(base int, yield func(int, T) bool) bool {
+	for i, value := range a.values {
+		if !yield(base+i, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *leafNode[T]) backward(base int, yield func(int, T) bool) bool {
+	for i := len(a.values) - 1; i >= 0; i-- {
+		if !yield(base+i, a.values[i]) {
+			return false
+		}
 	}
+	return true
 }
 
-func (a *leafNode) head(index int) node {
+func (a *leafNode[T]) head(index int, capacity int) node[T] {
 	currentSize := len(a.values)
 	if index < 0 || index > currentSize {
 		panic(fmt.Sprintf("invalid index for leaf node: %d", index))
 	}
 	if index == 0 {
-		return createEmptyLeafNode()
+		return createEmptyLeafNode[T]()
 	} else if index == currentSize {
 		return a
 	} else {
-		values := make([]Object, index)
+		values := make([]T, index)
 		copy(values[0:], a.values[0:index])
 		return createMultiValueLeafNode(values)
 	}
 }
 
-func (a *leafNode) tail(index int) node {
+func (a *leafNode[T]) tail(index int, capacity int) node[T] {
 	currentSize := len(a.values)
 	if index < 0 || index > currentSize {
 		panic(fmt.Sprintf("invalid index for leaf node: %d", index))
@@ -221,59 +320,59 @@ func (a *leafNode) tail(index int) node {
 	if index == 0 {
 		return a
 	} else if index == currentSize {
-		return createEmptyLeafNode()
+		return createEmptyLeafNode[T]()
 	} else {
-		values := make([]Object, currentSize-index)
+		values := make([]T, currentSize-index)
 		copy(values[0:], a.values[index:])
 		return createMultiValueLeafNode(values)
 	}
 }
 
-func (a *leafNode) left() node {
+func (a *leafNode[T]) left() node[T] {
 	panic("not implemented for leaf nodes")
 }
 
-func (a *leafNode) right() node {
+func (a *leafNode[T]) right() node[T] {
 	panic("not implemented for leaf nodes")
 }
 
-func (a *leafNode) depth() int {
+func (a *leafNode[T]) depth() int {
 	return 0
 }
 
-func (a *leafNode) size() int {
+func (a *leafNode[T]) size() int {
 	return len(a.values)
 }
 
-func (a *leafNode) appendNode(n node) node {
+func (a *leafNode[T]) appendNode(n node[T], capacity int) node[T] {
 	if n.size() == 0 {
 		return a
 	}
-	if o, matches := n.(*leafNode); matches {
+	if o, matches := n.(*leafNode[T]); matches {
 		combinedSize := a.size() + o.size()
-		if combinedSize <= maxValuesPerLeaf {
+		if combinedSize <= capacity {
 			return appendLeafNodeValues(combinedSize, a, o)
 		}
 	}
-	return createBranchNode(a, n)
+	return createBranchNode[T](a, n)
 }
 
-func (a *leafNode) prependNode(n node) node {
+func (a *leafNode[T]) prependNode(n node[T], capacity int) node[T] {
 	if n.size() == 0 {
 		return a
 	}
-	if o, matches := n.(*leafNode); matches {
+	if o, matches := n.(*leafNode[T]); matches {
 		combinedSize := o.size() + a.size()
-		if combinedSize <= maxValuesPerLeaf {
+		if combinedSize <= capacity {
 			return appendLeafNodeValues(combinedSize, o, a)
 		}
 	}
-	return createBranchNode(n, a)
+	return createBranchNode[T](n, a)
 }
 
-func (a *leafNode) next(state *iteratorState) (*iteratorState, Object) {
+func (a *leafNode[T]) next(state *iteratorState[T]) (*iteratorState[T], T) {
 	if state == nil || state.currentNode != a {
-		state = &iteratorState{currentNode: a, next: state}
+		state = &iteratorState[T]{currentNode: a, next: state}
 	}
 	value := a.values[state.currentIndex]
 	state.currentIndex++
@@ -284,58 +383,78 @@ func (a *leafNode) next(state *iteratorState) (*iteratorState, Object) {
 	}
 }
 
-func appendLeafNodeValues(combinedSize int, a *leafNode, b *leafNode) node {
-	values := make([]Object, combinedSize)
+// for reverse iteration currentIndex counts values already returned from the end of the leaf
+func (a *leafNode[T]) prev(state *iteratorState[T]) (*iteratorState[T], T) {
+	if state == nil || state.currentNode != a {
+		state = &iteratorState[T]{currentNode: a, next: state}
+	}
+	value := a.values[len(a.values)-1-state.currentIndex]
+	state.currentIndex++
+	if state.currentIndex == len(a.values) {
+		return state.next, value
+	} else {
+		return state, value
+	}
+}
+
+func (a *leafNode[T]) seek(state *iteratorState[T], index int) *iteratorState[T] {
+	return &iteratorState[T]{currentNode: a, currentIndex: index, next: state}
+}
+
+func (a *leafNode[T]) seekRev(state *iteratorState[T], index int) *iteratorState[T] {
+	return &iteratorState[T]{currentNode: a, currentIndex: len(a.values) - 1 - index, next: state}
+}
+
+func appendLeafNodeValues[T any](combinedSize int, a *leafNode[T], b *leafNode[T]) node[T] {
+	values := make([]T, combinedSize)
 	copy(values[0:], a.values)
 	copy(values[a.size():], b.values)
 	return createMultiValueLeafNode(values)
 }
 
-func (a *leafNode) checkInvariants(report reporter, isRoot bool) {
+func (a *leafNode[T]) checkInvariants(report reporter, isRoot bool, capacity int) {
 	currentSize := len(a.values)
-	if currentSize < 1 || currentSize > maxValuesPerLeaf {
-		report(fmt.Sprintf("incorrect size: currentSize=%d", currentSize))
+	if currentSize < 1 || currentSize > capacity {
+		report(fmt.Sprintf("incorrect size: currentSize=%d capacity=%d", currentSize, capacity))
 	}
 }
 
-func (a *leafNode) rotateLeft(parentLeft node) node {
+func (a *leafNode[T]) rotateLeft(parentLeft node[T]) node[T] {
 	panic("not implemented for leaf node")
 }
 
-func (a *leafNode) rotateRight(parentRight node) node {
+func (a *leafNode[T]) rotateRight(parentRight node[T]) node[T] {
 	panic("not implemented for leaf node")
 }
 
-type emptyNode struct {
+type emptyNode[T any] struct {
 }
 
-var sharedEmptyNode node = &emptyNode{}
-
-func createEmptyLeafNode() node {
-	return sharedEmptyNode
+func createEmptyLeafNode[T any]() node[T] {
+	return &emptyNode[T]{}
 }
 
-func (e *emptyNode) get(index int) Object {
+func (e *emptyNode[T]) get(index int) T {
 	panic("not implemented for empty nodes")
 }
 
-func (b *emptyNode) getFirst() Object {
+func (b *emptyNode[T]) getFirst() T {
 	panic("not implemented for empty nodes")
 }
 
-func (b *emptyNode) getLast() Object {
+func (b *emptyNode[T]) getLast() T {
 	panic("not implemented for empty nodes")
 }
 
-func (b *emptyNode) pop() (Object, node) {
+func (b *emptyNode[T]) pop() (T, node[T]) {
 	panic("not implemented for empty nodes")
 }
 
-func (b *emptyNode) set(index int, value Object) node {
+func (b *emptyNode[T]) set(index int, value T) node[T] {
 	panic("not implemented for empty nodes")
 }
 
-func (e *emptyNode) insert(index int, value Object) node {
+func (e *emptyNode[T]) insert(index int, value T, capacity int) node[T] {
 	if index == 0 {
 		return createSingleValueLeafNode(value)
 	} else {
@@ -343,11 +462,11 @@ func (e *emptyNode) insert(index int, value Object) node {
 	}
 }
 
-func (b *emptyNode) delete(index int) node {
+func (b *emptyNode[T]) delete(index int) node[T] {
 	panic("not implemented for empty nodes")
 }
 
-func (b *emptyNode) head(index int) node {
+func (b *emptyNode[T]) head(index int, capacity int) node[T] {
 	if index == 0 {
 		return b
 	} else {
@@ -355,7 +474,7 @@ func (b *emptyNode) head(index int) node {
 	}
 }
 
-func (b *emptyNode) tail(index int) node {
+func (b *emptyNode[T]) tail(index int, capacity int) node[T] {
 	if index == 0 {
 		return b
 	} else {
@@ -363,77 +482,106 @@ func (b *emptyNode) tail(index int) node {
 	}
 }
 
-func (e *emptyNode) append(value Object) node {
+func (e *emptyNode[T]) append(value T, capacity int) node[T] {
 	return createSingleValueLeafNode(value)
 }
 
-func (e *emptyNode) prepend(value Object) node {
+func (e *emptyNode[T]) prepend(value T, capacity int) node[T] {
 	return createSingleValueLeafNode(value)
 }
 
-func (e *emptyNode) forEach(proc Processor) {
+func (e *emptyNode[T]) forEach(proc Processor[T]) {
+}
+
+func (e *emptyNode[T]) mapValues(mapper func(T) T) node[T] {
+	return e
 }
 
-func (e *emptyNode) visit(base int, start int, limit int, v Visitor) {
+func (e *emptyNode[T]) visit(base int, start int, limit int, v VisitProc[T]) bool {
+	return false
+}
+
+func (e *emptyNode[T]) all(base int, yield func(int, T) bool) bool {
+	return true
+}
+
+func (e *emptyNode[T]) backward(base int, yield func(int, T) bool) bool {
+	return true
 }
 
-func (e *emptyNode) left() node {
+func (e *emptyNode[T]) left() node[T] {
 	panic("not implemented for empty nodes")
 }
 
-func (e *emptyNode) right() node {
+func (e *emptyNode[T]) right() node[T] {
 	panic("not implemented for empty nodes")
 }
 
-func (e *emptyNode) depth() int {
+func (e *emptyNode[T]) depth() int {
 	return 0
 }
 
-func (e *emptyNode) size() int {
+func (e *emptyNode[T]) size() int {
 	return 0
 }
 
-func (e *emptyNode) checkInvariants(report reporter, isRoot bool) {
+func (e *emptyNode[T]) checkInvariants(report reporter, isRoot bool, capacity int) {
 	if !isRoot {
 		report("emptyNode: should not exist below root")
 	}
 }
 
-func (e *emptyNode) rotateLeft(parentLeft node) node {
+func (e *emptyNode[T]) rotateLeft(parentLeft node[T]) node[T] {
 	panic("not implemented for leaf nodes")
 }
 
-func (e *emptyNode) rotateRight(parentRight node) node {
+func (e *emptyNode[T]) rotateRight(parentRight node[T]) node[T] {
 	panic("not implemented for leaf nodes")
 }
 
-func (b *emptyNode) appendNode(n node) node {
+func (b *emptyNode[T]) appendNode(n node[T], capacity int) node[T] {
 	if n.depth() != 0 {
 		panic("appending branch to leaf")
 	}
 	return n
 }
 
-func (b *emptyNode) prependNode(n node) node {
+func (b *emptyNode[T]) prependNode(n node[T], capacity int) node[T] {
 	if n.depth() != 0 {
 		panic("prepending branch to leaf")
 	}
 	return n
 }
 
-func (e *emptyNode) next(state *iteratorState) (*iteratorState, Object) {
-	return nil, nil
+func (e *emptyNode[T]) next(state *iteratorState[T]) (*iteratorState[T], T) {
+	var zero T
+	return nil, zero
 }
 
-type branchNode struct {
-	leftChild  node
-	rightChild node
+func (e *emptyNode[T]) prev(state *iteratorState[T]) (*iteratorState[T], T) {
+	var zero T
+	return nil, zero
+}
+
+func (e *emptyNode[T]) seek(state *iteratorState[T], index int) *iteratorState[T] {
+	panic("not implemented for empty nodes")
+}
+
+func (e *emptyNode[T]) seekRev(state *iteratorState[T], index int) *iteratorState[T] {
+	panic("not implemented for empty nodes")
+}
+
+type branchNode[T any] struct {
+	leftChild  node[T]
+	rightChild node[T]
 	mySize     int
 	myDepth    int
+	owner      *transientOwner // nil unless created by a transient
+	hash       atomic.Pointer[nodeHash[T]]
 }
 
-func createBranchNode(leftChild node, rightChild node) node {
-	return &branchNode{
+func createBranchNode[T any](leftChild node[T], rightChild node[T]) node[T] {
+	return &branchNode[T]{
 		leftChild:  leftChild,
 		rightChild: rightChild,
 		mySize:     leftChild.size() + rightChild.size(),
@@ -441,7 +589,7 @@ func createBranchNode(leftChild node, rightChild node) node {
 	}
 }
 
-func createBalancedBranchNode(left node, right node) node {
+func createBalancedBranchNode[T any](left node[T], right node[T]) node[T] {
 	diff := left.depth() - right.depth()
 	if diff > 1 {
 		return left.rotateRight(right)
@@ -452,25 +600,42 @@ func createBalancedBranchNode(left node, right node) node {
 	}
 }
 
-func (b *branchNode) append(value Object) node {
-	return createBalancedBranchNode(b.leftChild, b.rightChild.append(value))
+func (b *branchNode[T]) append(value T, capacity int) node[T] {
+	return createBalancedBranchNode(b.leftChild, b.rightChild.append(value, capacity))
 }
 
-func (b *branchNode) prepend(value Object) node {
-	return createBalancedBranchNode(b.leftChild.prepend(value), b.rightChild)
+func (b *branchNode[T]) prepend(value T, capacity int) node[T] {
+	return createBalancedBranchNode(b.leftChild.prepend(value, capacity), b.rightChild)
 }
 
-func (b *branchNode) forEach(proc Processor) {
+func (b *branchNode[T]) forEach(proc Processor[T]) {
 	b.leftChild.forEach(proc)
 	b.rightChild.forEach(proc)
 }
 
-func (b *branchNode) visit(base int, start int, limit int, v Visitor) {
-	visitNode(b.leftChild, 0, base, start, limit, v)
-	visitNode(b.rightChild, b.leftChild.size(), base, start, limit, v)
+func (b *branchNode[T]) mapValues(mapper func(T) T) node[T] {
+	return &branchNode[T]{
+		leftChild:  b.leftChild.mapValues(mapper),
+		rightChild: b.rightChild.mapValues(mapper),
+		mySize:     b.mySize,
+		myDepth:    b.myDepth,
+	}
+}
+
+func (b *branchNode[T]) visit(base int, start int, limit int, v VisitProc[T]) bool {
+	return visitNode(b.leftChild, 0, base, start, limit, v) ||
+		visitNode(b.rightChild, b.leftChild.size(), base, start, limit, v)
+}
+
+func (b *branchNode[T]) all(base int, yield func(int, T) bool) bool {
+	return b.leftChild.all(base, yield) && b.rightChild.all(base+b.leftChild.size(), yield)
 }
 
-func visitNode(node node, offset int, base int, start int, limit int, v Visitor) {
+func (b *branchNode[T]) backward(base int, yield func(int, T) bool) bool {
+	return b.rightChild.backward(base+b.leftChild.size(), yield) && b.leftChild.backward(base, yield)
+}
+
+func visitNode[T any](node node[T], offset int, base int, start int, limit int, v VisitProc[T]) bool {
 	base += offset
 	start -= offset
 	limit -= offset
@@ -481,11 +646,12 @@ func visitNode(node node, offset int, base int, start int, limit int, v Visitor)
 		limit = node.size()
 	}
 	if limit > start {
-		node.visit(base, start, limit, v)
+		return node.visit(base, start, limit, v)
 	}
+	return false
 }
 
-func maxDepth(leftChild node, rightChild node) int {
+func maxDepth[T any](leftChild node[T], rightChild node[T]) int {
 	leftDepth, rightDepth := leftChild.depth(), rightChild.depth()
 	if leftDepth > rightDepth {
 		return leftDepth
@@ -494,7 +660,7 @@ func maxDepth(leftChild node, rightChild node) int {
 	}
 }
 
-func depthDiff(leftChild node, rightChild node) int {
+func depthDiff[T any](leftChild node[T], rightChild node[T]) int {
 	leftDepth, rightDepth := leftChild.depth(), rightChild.depth()
 	if leftDepth > rightDepth {
 		return leftDepth - rightDepth
@@ -503,7 +669,7 @@ func depthDiff(leftChild node, rightChild node) int {
 	}
 }
 
-func (b *branchNode) get(index int) Object {
+func (b *branchNode[T]) get(index int) T {
 	leftSize := b.leftChild.size()
 	if index < leftSize {
 		return b.leftChild.get(index)
@@ -512,15 +678,15 @@ func (b *branchNode) get(index int) Object {
 	}
 }
 
-func (b *branchNode) getFirst() Object {
+func (b *branchNode[T]) getFirst() T {
 	return b.leftChild.getFirst()
 }
 
-func (b *branchNode) getLast() Object {
+func (b *branchNode[T]) getLast() T {
 	return b.rightChild.getLast()
 }
 
-func (b *branchNode) pop() (Object, node) {
+func (b *branchNode[T]) pop() (T, node[T]) {
 	value, newLeft := b.leftChild.pop()
 	if newLeft.size() == 0 {
 		return value, b.rightChild
@@ -529,7 +695,7 @@ func (b *branchNode) pop() (Object, node) {
 	}
 }
 
-func (b *branchNode) set(index int, value Object) node {
+func (b *branchNode[T]) set(index int, value T) node[T] {
 	leftSize := b.leftChild.size()
 	if index < leftSize {
 		return createBranchNode(b.leftChild.set(index, value), b.rightChild)
@@ -538,7 +704,7 @@ func (b *branchNode) set(index int, value Object) node {
 	}
 }
 
-func (b *branchNode) rotateLeft(parentLeft node) node {
+func (b *branchNode[T]) rotateLeft(parentLeft node[T]) node[T] {
 	if b.leftChild.depth() > b.rightChild.depth() {
 		return createBranchNode(createBranchNode(parentLeft, b.leftChild.left()), createBranchNode(b.leftChild.right(), b.rightChild))
 	} else {
@@ -546,7 +712,7 @@ func (b *branchNode) rotateLeft(parentLeft node) node {
 	}
 }
 
-func (b *branchNode) rotateRight(parentRight node) node {
+func (b *branchNode[T]) rotateRight(parentRight node[T]) node[T] {
 	if b.leftChild.depth() >= b.rightChild.depth() {
 		return createBranchNode(b.leftChild, createBranchNode(b.rightChild, parentRight))
 	} else {
@@ -554,22 +720,22 @@ func (b *branchNode) rotateRight(parentRight node) node {
 	}
 }
 
-func (b *branchNode) insert(index int, value Object) node {
-	var newLeft node
-	var newRight node
+func (b *branchNode[T]) insert(index int, value T, capacity int) node[T] {
+	var newLeft node[T]
+	var newRight node[T]
 	leftSize := b.leftChild.size()
 	if index < leftSize {
-		newLeft = b.leftChild.insert(index, value)
+		newLeft = b.leftChild.insert(index, value, capacity)
 		newRight = b.rightChild
 	} else {
 		newLeft = b.leftChild
-		newRight = b.rightChild.insert(index-leftSize, value)
+		newRight = b.rightChild.insert(index-leftSize, value, capacity)
 	}
 	return createBalancedBranchNode(newLeft, newRight)
 }
 
-func (b *branchNode) delete(index int) node {
-	var newLeft, newRight node
+func (b *branchNode[T]) delete(index int) node[T] {
+	var newLeft, newRight node[T]
 	leftSize := b.leftChild.size()
 	if index < leftSize {
 		newLeft = b.leftChild.delete(index)
@@ -587,77 +753,77 @@ func (b *branchNode) delete(index int) node {
 	return createBalancedBranchNode(newLeft, newRight)
 }
 
-func (b *branchNode) head(index int) node {
+func (b *branchNode[T]) head(index int, capacity int) node[T] {
 	leftSize := b.leftChild.size()
 	if index < leftSize {
-		return b.leftChild.head(index)
+		return b.leftChild.head(index, capacity)
 	} else {
-		newRight := b.rightChild.head(index - leftSize)
-		return appendNodes(b.leftChild, newRight)
+		newRight := b.rightChild.head(index-leftSize, capacity)
+		return appendNodes(b.leftChild, newRight, capacity)
 	}
 }
 
-func (b *branchNode) tail(index int) node {
+func (b *branchNode[T]) tail(index int, capacity int) node[T] {
 	leftSize := b.leftChild.size()
 	if index < leftSize {
-		newLeft := b.leftChild.tail(index)
-		return appendNodes(newLeft, b.rightChild)
+		newLeft := b.leftChild.tail(index, capacity)
+		return appendNodes(newLeft, b.rightChild, capacity)
 	} else {
-		return b.rightChild.tail(index - leftSize)
+		return b.rightChild.tail(index-leftSize, capacity)
 	}
 }
 
-func (b *branchNode) left() node {
+func (b *branchNode[T]) left() node[T] {
 	return b.leftChild
 }
 
-func (b *branchNode) right() node {
+func (b *branchNode[T]) right() node[T] {
 	return b.rightChild
 }
 
-func (b *branchNode) depth() int {
+func (b *branchNode[T]) depth() int {
 	return b.myDepth
 }
 
-func (b *branchNode) size() int {
+func (b *branchNode[T]) size() int {
 	return b.mySize
 }
 
-func (b *branchNode) appendNode(n node) node {
+func (b *branchNode[T]) appendNode(n node[T], capacity int) node[T] {
 	if n.depth() > b.depth() {
 		panic("appending larger node to smaller node")
 	}
-	if depthDiff(n, b) <= 1 {
-		return createBranchNode(b, n)
+	if depthDiff[T](n, b) <= 1 {
+		return createBranchNode[T](b, n)
 	} else {
-		return createBalancedBranchNode(b.leftChild, b.rightChild.appendNode(n))
+		return createBalancedBranchNode(b.leftChild, b.rightChild.appendNode(n, capacity))
 	}
 }
 
-func (b *branchNode) prependNode(n node) node {
+func (b *branchNode[T]) prependNode(n node[T], capacity int) node[T] {
 	if n.depth() > b.depth() {
 		panic("prepending larger node to smaller node")
 	}
-	if depthDiff(n, b) <= 1 {
-		return createBranchNode(n, b)
+	if depthDiff[T](n, b) <= 1 {
+		return createBranchNode[T](n, b)
 	} else {
-		return createBalancedBranchNode(b.leftChild.prependNode(n), b.rightChild)
+		return createBalancedBranchNode(b.leftChild.prependNode(n, capacity), b.rightChild)
 	}
 }
 
-func appendNodes(a node, b node) node {
+func appendNodes[T any](a node[T], b node[T], capacity int) node[T] {
 	if a.size() == 0 {
 		return b
 	} else if b.size() == 0 {
 		return a
 	} else if a.depth() < b.depth() {
-		return b.prependNode(a)
+		return b.prependNode(a, capacity)
 	} else {
-		return a.appendNode(b)
+		return a.appendNode(b, capacity)
 	}
 }
 
-func (b *branchNode) checkInvariants(report reporter, isRoot bool) {
+func (b *branchNode[T]) checkInvariants(report reporter, isRoot bool, capacity int) {
 	if b.depth() != maxDepth(b.leftChild, b.rightChild)+1 {
 		report(fmt.Sprintf("incorrect depth: depth=%d leftDepth=%d rightDepth=%d", b.depth(), b.leftChild.depth(), b.rightChild.depth()))
 	}
@@ -667,13 +833,13 @@ func (b *branchNode) checkInvariants(report reporter, isRoot bool) {
 	if b.size() != b.leftChild.size()+b.rightChild.size() {
 		report(fmt.Sprintf("incorrect size: size=%d leftSize=%d rightSize=%d", b.size(), b.leftChild.size(), b.rightChild.size()))
 	}
-	b.leftChild.checkInvariants(report, false)
-	b.rightChild.checkInvariants(report, false)
+	b.leftChild.checkInvariants(report, false, capacity)
+	b.rightChild.checkInvariants(report, false, capacity)
 }
 
-func (b *branchNode) next(state *iteratorState) (*iteratorState, Object) {
+func (b *branchNode[T]) next(state *iteratorState[T]) (*iteratorState[T], T) {
 	if state == nil || state.currentNode != b {
-		state = &iteratorState{currentNode: b, next: state}
+		state = &iteratorState[T]{currentNode: b, next: state}
 	}
 	switch state.currentIndex {
 	case 0:
@@ -686,3 +852,37 @@ func (b *branchNode) next(state *iteratorState) (*iteratorState, Object) {
 		panic("invalid index in iterator state")
 	}
 }
+
+func (b *branchNode[T]) prev(state *iteratorState[T]) (*iteratorState[T], T) {
+	if state == nil || state.currentNode != b {
+		state = &iteratorState[T]{currentNode: b, next: state}
+	}
+	switch state.currentIndex {
+	case 0:
+		state.currentIndex = 1
+		return b.rightChild.prev(state)
+	case 1:
+		state.currentIndex = 2
+		return b.leftChild.prev(state.next)
+	default:
+		panic("invalid index in iterator state")
+	}
+}
+
+func (b *branchNode[T]) seek(state *iteratorState[T], index int) *iteratorState[T] {
+	leftSize := b.leftChild.size()
+	if index < leftSize {
+		return b.leftChild.seek(&iteratorState[T]{currentNode: b, currentIndex: 1, next: state}, index)
+	} else {
+		return b.rightChild.seek(state, index-leftSize)
+	}
+}
+
+func (b *branchNode[T]) seekRev(state *iteratorState[T], index int) *iteratorState[T] {
+	leftSize := b.leftChild.size()
+	if index < leftSize {
+		return b.leftChild.seekRev(state, index)
+	} else {
+		return b.rightChild.seekRev(&iteratorState[T]{currentNode: b, currentIndex: 1, next: state}, index-leftSize)
+	}
+}
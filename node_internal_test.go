@@ -7,34 +7,34 @@ import (
 )
 
 func TestNodeAppend(t *testing.T) {
-	expected := make([]Object, 0)
-	var list node = &emptyNode{}
+	expected := make([]string, 0)
+	var list node[string] = &emptyNode[string]{}
 	for length := 0; length <= 4096; length += 1 {
 		expected = insertToSlice(expected, length, val(length))
-		list = list.insert(length, val(length))
+		list = list.insert(length, val(length), defaultLeafCapacity)
 		validateNode(t, list, expected)
 	}
 }
 
 func TestNodePrepend(t *testing.T) {
-	expected := make([]Object, 0)
-	var list node = &emptyNode{}
+	expected := make([]string, 0)
+	var list node[string] = &emptyNode[string]{}
 	for length := 0; length <= 4096; length += 1 {
 		expected = insertToSlice(expected, 0, val(length))
-		list = list.insert(0, val(length))
+		list = list.insert(0, val(length), defaultLeafCapacity)
 		validateNode(t, list, expected)
 	}
 }
 
 func TestNodeInsert(t *testing.T) {
-	expected := make([]Object, 0)
-	var list node = &emptyNode{}
+	expected := make([]string, 0)
+	var list node[string] = &emptyNode[string]{}
 	expected = insertToSlice(expected, 0, val(0))
-	list = list.insert(0, val(0))
+	list = list.insert(0, val(0), defaultLeafCapacity)
 	for length := 1; length <= 4096; length += 1 {
 		index := rand.Intn(length)
 		expected = insertToSlice(expected, index, val(length))
-		list = list.insert(index, val(length))
+		list = list.insert(index, val(length), defaultLeafCapacity)
 		validateNode(t, list, expected)
 	}
 }
@@ -42,7 +42,7 @@ func TestNodeInsert(t *testing.T) {
 func TestNodePop(t *testing.T) {
 	b, e := listAppendLists(1024)
 	for len(e) > 0 {
-		var value Object
+		var value string
 		value, b = b.pop()
 		if value != e[0] {
 			t.Error(fmt.Sprintf("incorrect value from pop(): expected=%v actual=%v", e[0], value))
@@ -63,11 +63,11 @@ func TestNodeSet(t *testing.T) {
 }
 
 func TestNodeGetFirstLast(t *testing.T) {
-	expected := make([]Object, 0)
-	var list node = &emptyNode{}
+	expected := make([]string, 0)
+	var list node[string] = &emptyNode[string]{}
 	for length := 0; length <= 30; length += 1 {
 		expected = insertToSlice(expected, length, val(length))
-		list = list.insert(length, val(length))
+		list = list.insert(length, val(length), defaultLeafCapacity)
 		if expected[0] != list.getFirst() {
 			t.Error(fmt.Sprintf("incorrect value from getFirst(): expected=%v actual=%v", expected[0], list.getFirst()))
 		}
@@ -84,7 +84,7 @@ func TestNodeAppendList(t *testing.T) {
 		ab, ae := listAppendLists(alen)
 		bb, be := listAppendLists(blen)
 		expected := append(ae, be...)
-		list := appendNodes(ab, bb)
+		list := appendNodes(ab, bb, defaultLeafCapacity)
 		validateNode(t, list, expected)
 	}
 }
@@ -106,7 +106,7 @@ func TestNodeHead(t *testing.T) {
 		list, expected := listAppendLists(loop)
 		for list.size() > 0 {
 			index := rand.Intn(list.size() + 1)
-			list = list.head(index)
+			list = list.head(index, defaultLeafCapacity)
 			for len(expected) > index {
 				expected = deleteFromSlice(expected, index)
 			}
@@ -120,7 +120,7 @@ func TestNodeTail(t *testing.T) {
 		list, expected := listAppendLists(loop)
 		for list.size() > 0 {
 			index := rand.Intn(list.size() + 1)
-			list = list.tail(index)
+			list = list.tail(index, defaultLeafCapacity)
 			for i := 0; i < index; i++ {
 				expected = deleteFromSlice(expected, 0)
 			}
@@ -131,15 +131,31 @@ func TestNodeTail(t *testing.T) {
 
 func TestNodeIterator(t *testing.T) {
 	for length := 0; length <= 1024; length++ {
-		expected := make([]Object, 0)
-		var list node = &emptyNode{}
+		expected := make([]string, 0)
+		var list node[string] = &emptyNode[string]{}
 		for i := 0; i <= length; i += 1 {
 			expected = insertToSlice(expected, i, val(i))
-			list = list.append(val(i))
+			list = list.append(val(i), defaultLeafCapacity)
 		}
-		actual := createEmptyLeafNode()
+		actual := createEmptyLeafNode[string]()
 		for i := createIterator(list); i.Next(); {
-			actual = actual.insert(actual.size(), i.Get())
+			actual = actual.insert(actual.size(), i.Get(), defaultLeafCapacity)
+		}
+		validateNode(t, actual, expected)
+	}
+}
+
+func TestNodeRevIterator(t *testing.T) {
+	for length := 0; length <= 1024; length++ {
+		expected := make([]string, 0)
+		var list node[string] = &emptyNode[string]{}
+		for i := 0; i <= length; i += 1 {
+			expected = insertToSlice(expected, i, val(i))
+			list = list.append(val(i), defaultLeafCapacity)
+		}
+		actual := createEmptyLeafNode[string]()
+		for i := createRevIterator(list); i.Next(); {
+			actual = actual.insert(0, i.Get(), defaultLeafCapacity)
 		}
 		validateNode(t, actual, expected)
 	}
@@ -187,15 +203,15 @@ func benchmarkNodeDelete(size int, b *testing.B) {
 	}
 }
 
-func createNodeListForBenchmark(size int) node {
-	list := createEmptyLeafNode()
+func createNodeListForBenchmark(size int) node[string] {
+	list := createEmptyLeafNode[string]()
 	for i := 1; i <= size; i++ {
-		list = list.append(val(i))
+		list = list.append(val(i), defaultLeafCapacity)
 	}
 	return list
 }
 
-func validateNode(t *testing.T, b node, e []Object) {
+func validateNode(t *testing.T, b node[string], e []string) {
 	if b.size() != len(e) {
 		t.Error(fmt.Sprintf("incorrect size: b=%d e=%d", b.size(), len(e)))
 	}
@@ -206,16 +222,16 @@ func validateNode(t *testing.T, b node, e []Object) {
 	}
 	b.checkInvariants(func(message string) {
 		t.Error(message)
-	}, true)
+	}, true, defaultLeafCapacity)
 }
 
-func listAppendLists(length int) (node, []Object) {
-	expected := make([]Object, 0)
-	var list node = &emptyNode{}
+func listAppendLists(length int) (node[string], []string) {
+	expected := make([]string, 0)
+	var list node[string] = &emptyNode[string]{}
 	for i := 0; i < length; i += 1 {
 		value := val(i)
 		expected = insertToSlice(expected, i, value)
-		list = list.insert(i, value)
+		list = list.insert(i, value, defaultLeafCapacity)
 	}
 	return list, expected
 }
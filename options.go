@@ -0,0 +1,95 @@
+package immutableList
+
+import "fmt"
+
+// Option customizes a List created by CreateWithOptions.  Lists derived from
+// that List through its methods keep the same options.
+type Option func(*options)
+
+type options struct {
+	hasher       *hasherToken
+	codec        any
+	formatLimit  int
+	leafCapacity int
+}
+
+const defaultFormatLimit = 100
+
+// WithHasher enables List.Hash() using hasher to hash individual values.
+func WithHasher[T any](hasher func(T) uint64) Option {
+	token := &hasherToken{hasher: hasher}
+	return func(o *options) {
+		o.hasher = token
+	}
+}
+
+// WithCodec enables binary encoding and decoding of a List using codec
+// to encode individual values.
+func WithCodec[T any](codec Codec[T]) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
+
+// WithLeafCapacity sets the maximum number of values stored in each leaf of
+// the tree.  Larger leaves suit small values that are mostly read or appended
+// while smaller leaves make Set and Insert copy less.  The default is 32.
+func WithLeafCapacity(capacity int) Option {
+	if capacity < 2 {
+		panic(fmt.Sprintf("invalid leaf capacity: %d", capacity))
+	}
+	return func(o *options) {
+		o.leafCapacity = capacity
+	}
+}
+
+// WithFormatLimit sets the number of values included when a List is
+// formatted or logged.  The remaining values are summarized as "... N more".
+// A negative limit includes every value.  The default is 100.
+func WithFormatLimit(limit int) Option {
+	return func(o *options) {
+		o.formatLimit = limit
+	}
+}
+
+type listConfig[T any] struct {
+	hasher       func(T) uint64
+	hasherToken  *hasherToken
+	codec        Codec[T]
+	formatLimit  int
+	leafCapacity int
+}
+
+func defaultListConfig[T any]() *listConfig[T] {
+	return &listConfig[T]{formatLimit: defaultFormatLimit, leafCapacity: defaultLeafCapacity}
+}
+
+func CreateWithOptions[T any](opts ...Option) List[T] {
+	return createListNode(createListConfig[T](opts), createEmptyLeafNode[T]())
+}
+
+func createListConfig[T any](opts []Option) *listConfig[T] {
+	o := options{formatLimit: defaultFormatLimit, leafCapacity: defaultLeafCapacity}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	config := defaultListConfig[T]()
+	config.formatLimit = o.formatLimit
+	config.leafCapacity = o.leafCapacity
+	if o.hasher != nil {
+		hasher, matches := o.hasher.hasher.(func(T) uint64)
+		if !matches {
+			panic(fmt.Sprintf("hasher type does not match list element type: %T", o.hasher.hasher))
+		}
+		config.hasher = hasher
+		config.hasherToken = o.hasher
+	}
+	if o.codec != nil {
+		codec, matches := o.codec.(Codec[T])
+		if !matches {
+			panic(fmt.Sprintf("codec type does not match list element type: %T", o.codec))
+		}
+		config.codec = codec
+	}
+	return config
+}
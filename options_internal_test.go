@@ -0,0 +1,101 @@
+package immutableList
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestLeafCapacity(t *testing.T) {
+	for _, capacity := range []int{8, 128} {
+		option := WithLeafCapacity(capacity)
+		expected := make([]string, 0)
+		list := CreateWithOptions[string](option)
+		for i := 0; i < 1000; i++ {
+			index := rand.Intn(len(expected) + 1)
+			expected = insertToSlice(expected, index, val(i))
+			list = list.Insert(index, val(i))
+		}
+		validateList3(t, list, expected)
+
+		for i := 0; i < 300; i++ {
+			index := rand.Intn(len(expected))
+			expected = deleteFromSlice(expected, index)
+			list = list.Delete(index)
+		}
+		validateList3(t, list, expected)
+		validateList3(t, list.Head(333).AppendList(list.Tail(333)), expected)
+		validateList3(t, list.SubList(10, 500), expected[10:500])
+		sorted := append([]string(nil), expected...)
+		sort.Strings(sorted)
+		validateList3(t, list.Sort(func(a, b string) bool { return a < b }), sorted)
+
+		builder := CreateBuilder[string](option)
+		for i := 1; i <= 1000; i++ {
+			builder.Add(val(i))
+		}
+		built := builder.Build()
+		validateList(t, built, 1000)
+		validateLeafCapacity(t, built, capacity)
+		validateLeafCapacity(t, FromSlice(built.Slice(0, 1000), option), capacity)
+		validateLeafCapacity(t, FromIterator(built.FwdIterate(), option), capacity)
+
+		transient := built.Transient()
+		for i := 1000; i < 2000; i++ {
+			transient.Append(val(i + 1))
+		}
+		persistent := transient.Persistent()
+		validateList(t, persistent, 2000)
+		validateLeafCapacity(t, persistent, capacity)
+		validateSize(t, len(persistent.Stats().LeafFill), capacity+1)
+	}
+}
+
+func TestLeafCapacityMixed(t *testing.T) {
+	narrow := FromSlice(createListForTest(1, 100).Slice(0, 100), WithLeafCapacity(8))
+	wide := FromSlice(createListForTest(101, 300).Slice(0, 200), WithLeafCapacity(128))
+	validateList(t, narrow.AppendList(wide), 300)
+	validateList(t, wide.InsertList(0, narrow), 300)
+	validateLeafCapacity(t, narrow.AppendList(wide), 8)
+
+	var buffer bytes.Buffer
+	if err := WriteTree(&buffer, narrow.Head(5)); err != nil {
+		t.Fatal(err)
+	}
+	validateFormat(t, buffer.String(), "leaf 5/8\n")
+
+	codec := WithCodec[string](StringCodec{})
+	buffer.Reset()
+	if _, err := EncodeVersions(&buffer, []List[string]{FromSlice(wide.Slice(0, 200), codec, WithLeafCapacity(128))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeVersions[string](bytes.NewReader(buffer.Bytes()), codec); err == nil || !strings.Contains(err.Error(), "invalid leaf size") {
+		t.Error(fmt.Sprintf("expected leaf size error but got %v", err))
+	}
+	decoded, err := DecodeVersions[string](bytes.NewReader(buffer.Bytes()), codec, WithLeafCapacity(128))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateList2(t, decoded[0], 101, 300)
+}
+
+func validateLeafCapacity(t *testing.T, list List[string], capacity int) {
+	full := 0
+	list.checkInvariants(func(message string) {
+		t.Error(message)
+	})
+	for fill, count := range list.Stats().LeafFill {
+		if fill > capacity && count > 0 {
+			t.Error(fmt.Sprintf("leaf exceeds capacity: fill=%d capacity=%d", fill, capacity))
+		}
+		if fill == capacity {
+			full += count
+		}
+	}
+	if full == 0 {
+		t.Error(fmt.Sprintf("expected full leaves: capacity=%d", capacity))
+	}
+}
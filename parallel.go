@@ -0,0 +1,100 @@
+package immutableList
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// ParallelForEach calls proc once for every value using up to workers
+// goroutines.  Branch nodes are split between goroutines until each one has
+// a subtree of its own which it then processes in order.  proc must be safe
+// for concurrent use and the order of calls across subtrees is undefined.
+func (this *listImpl[T]) ParallelForEach(workers int, proc Processor[T]) {
+	if workers < 1 {
+		panic(fmt.Sprintf("invalid worker count: %d", workers))
+	}
+	parallelForEach(this.root, workers, proc)
+}
+
+// ParallelMap is like Map but calls mapper using up to workers goroutines.
+// The result has the same shape as this list and values keep their order.
+func (this *listImpl[T]) ParallelMap(workers int, mapper func(T) T) List[T] {
+	if workers < 1 {
+		panic(fmt.Sprintf("invalid worker count: %d", workers))
+	}
+	return createListNode(this.config, parallelMapValues(this.root, workers, mapper))
+}
+
+// the left child runs on a new goroutine with half of the workers while the
+// current goroutine keeps the rest for the right child
+func parallelForEach[T any](n node[T], workers int, proc Processor[T]) {
+	if workers == 1 || n.depth() == 0 {
+		n.forEach(proc)
+		return
+	}
+	var group sync.WaitGroup
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		parallelForEach(n.left(), workers/2, proc)
+	}()
+	parallelForEach(n.right(), workers-workers/2, proc)
+	group.Wait()
+}
+
+func parallelMapValues[T any](n node[T], workers int, mapper func(T) T) node[T] {
+	if workers == 1 || n.depth() == 0 {
+		return n.mapValues(mapper)
+	}
+	var group sync.WaitGroup
+	var left node[T]
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		left = parallelMapValues(n.left(), workers/2, mapper)
+	}()
+	right := parallelMapValues(n.right(), workers-workers/2, mapper)
+	group.Wait()
+	return createBranchNode(left, right)
+}
+
+// ParallelReduce combines the values of list into a single result.  mapLeaf
+// reduces the values of one leaf and combine merges the results of adjacent
+// ranges, left range first.  The children of branches holding more than
+// threshold values are reduced concurrently, so combine must be associative
+// and both functions must be safe for concurrent use.  identity is returned
+// for an empty list.
+func ParallelReduce[T any, A any](list List[T], threshold int, identity A, mapLeaf func(values iter.Seq[T]) A, combine func(a, b A) A) A {
+	if threshold < 1 {
+		panic(fmt.Sprintf("invalid threshold: %d", threshold))
+	}
+	root := list.(*listImpl[T]).root
+	if root.size() == 0 {
+		return identity
+	}
+	return parallelReduceNode(root, threshold, mapLeaf, combine)
+}
+
+func parallelReduceNode[T any, A any](n node[T], threshold int, mapLeaf func(values iter.Seq[T]) A, combine func(a, b A) A) A {
+	if n.depth() == 0 {
+		return mapLeaf(func(yield func(T) bool) {
+			n.all(0, func(_ int, value T) bool {
+				return yield(value)
+			})
+		})
+	}
+	if n.size() <= threshold {
+		return combine(parallelReduceNode(n.left(), threshold, mapLeaf, combine), parallelReduceNode(n.right(), threshold, mapLeaf, combine))
+	}
+	var group sync.WaitGroup
+	var left A
+	group.Add(1)
+	go func() {
+		defer group.Done()
+		left = parallelReduceNode(n.left(), threshold, mapLeaf, combine)
+	}()
+	right := parallelReduceNode(n.right(), threshold, mapLeaf, combine)
+	group.Wait()
+	return combine(left, right)
+}
@@ -0,0 +1,86 @@
+package immutableList
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParallelForEach(t *testing.T) {
+	for _, length := range []int{0, 1, 40, 5000} {
+		list := createListForTest(1, length)
+		for _, workers := range []int{1, 3, 8, 1000} {
+			var lock sync.Mutex
+			seen := make(map[string]int)
+			list.ParallelForEach(workers, func(value string) {
+				lock.Lock()
+				defer lock.Unlock()
+				seen[value]++
+			})
+			validateSize(t, len(seen), length)
+			for i := 1; i <= length; i++ {
+				if seen[val(i)] != 1 {
+					t.Error(fmt.Sprintf("expected one call: value=%s calls=%d", val(i), seen[val(i)]))
+				}
+			}
+		}
+	}
+}
+
+func TestParallelMap(t *testing.T) {
+	mapper := func(value string) string {
+		return value + "!"
+	}
+	list := createListForTestReverseDirectly(1, 5000)
+	expected := list.Map(mapper)
+	for _, workers := range []int{1, 3, 8, 1000} {
+		mapped := list.ParallelMap(workers, mapper)
+		validateList3(t, mapped, expected.Slice(0, expected.Size()))
+		validateSameShape(t, mapped, list)
+	}
+	if !Create[string]().ParallelMap(4, mapper).IsEmpty() {
+		t.Error("expected empty list")
+	}
+}
+
+func TestParallelReduce(t *testing.T) {
+	sum := func(values iter.Seq[int]) int {
+		answer := 0
+		for value := range values {
+			answer += value
+		}
+		return answer
+	}
+	add := func(a, b int) int {
+		return a + b
+	}
+	for _, threshold := range []int{1, 100, 1000000} {
+		values := make([]int, 100000)
+		for i := range values {
+			values[i] = i
+		}
+		validateSize(t, ParallelReduce(FromSlice(values), threshold, 0, sum, add), 100000*99999/2)
+		validateSize(t, ParallelReduce(Create[int](), threshold, -1, sum, add), -1)
+
+		list := createListForTestReverseDirectly(1, 3000)
+		joined := ParallelReduce(list, threshold, "", func(values iter.Seq[string]) string {
+			return strings.Join(slices.Collect(values), ",")
+		}, func(a, b string) string {
+			return a + "," + b
+		})
+		validateFormat(t, joined, strings.Join(list.Slice(0, 3000), ","))
+	}
+}
+
+func validateSameShape(t *testing.T, actual List[string], expected List[string]) {
+	var actualTree, expectedTree bytes.Buffer
+	WriteTree(&actualTree, actual)
+	WriteTree(&expectedTree, expected)
+	if actualTree.String() != expectedTree.String() {
+		t.Error("expected lists to have the same shape")
+	}
+}
@@ -0,0 +1,55 @@
+package immutableList
+
+import "fmt"
+
+// Hunk replaces the values in [Offset, Limit) of the original list with Values.
+// Inserts have Offset == Limit and deletes have empty Values.
+type Hunk[T any] struct {
+	Offset int
+	Limit  int
+	Values List[T]
+}
+
+// Patch is a sequence of hunks ordered by offset.  The ranges of the hunks
+// refer to the list the patch is applied to and must not overlap.
+type Patch[T any] struct {
+	Hunks []Hunk[T]
+}
+
+// CreatePatch converts the edits returned by Diff into a Patch that turns the
+// old list into new.  The hunk values share structure with new.
+func CreatePatch[T any](edits []Edit, new List[T]) Patch[T] {
+	hunks := make([]Hunk[T], len(edits))
+	for i, edit := range edits {
+		hunks[i] = Hunk[T]{Offset: edit.OldOffset, Limit: edit.OldLimit, Values: new.SubList(edit.NewOffset, edit.NewLimit)}
+	}
+	return Patch[T]{Hunks: hunks}
+}
+
+// Apply returns a list with every hunk of patch applied.  The hunks are
+// applied in a single pass from left to right, so unchanged ranges between
+// them are shared with this list rather than copied.
+func (this *listImpl[T]) Apply(patch Patch[T]) (List[T], error) {
+	size := this.Size()
+	previousLimit := 0
+	for i, hunk := range patch.Hunks {
+		if hunk.Offset < previousLimit || hunk.Limit < hunk.Offset || hunk.Limit > size {
+			return nil, fmt.Errorf("invalid hunk: index=%d size=%d offset=%d limit=%d", i, size, hunk.Offset, hunk.Limit)
+		}
+		previousLimit = hunk.Limit
+	}
+
+	capacity := this.config.leafCapacity
+	answer := createEmptyLeafNode[T]()
+	remaining := this.root
+	position := 0
+	for _, hunk := range patch.Hunks {
+		answer = appendNodes(answer, remaining.head(hunk.Offset-position, capacity), capacity)
+		if hunk.Values != nil {
+			answer = appendNodes(answer, this.adoptRoot(hunk.Values), capacity)
+		}
+		remaining = remaining.tail(hunk.Limit-position, capacity)
+		position = hunk.Limit
+	}
+	return createListNode(this.config, appendNodes(answer, remaining, capacity)), nil
+}
@@ -0,0 +1,58 @@
+package immutableList
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	for loop := 0; loop < 200; loop++ {
+		list := createListForTest(1, rand.Intn(2000))
+		expected := make([]string, 0)
+		hunks := make([]Hunk[string], 0)
+		position := 0
+		for position < list.Size() && rand.Intn(8) != 0 {
+			offset := position + rand.Intn(list.Size()-position+1)
+			limit := offset + rand.Intn(list.Size()-offset+1)
+			values := createListForTest(10000, 10000+rand.Intn(50)-10)
+			hunks = append(hunks, Hunk[string]{Offset: offset, Limit: limit, Values: values})
+			expected = append(expected, list.Slice(position, offset)...)
+			expected = append(expected, values.Slice(0, values.Size())...)
+			position = limit
+		}
+		expected = append(expected, list.Slice(position, list.Size())...)
+		actual, err := list.Apply(Patch[string]{Hunks: hunks})
+		if err != nil {
+			t.Error(err)
+		} else {
+			validateList3(t, actual, expected)
+		}
+	}
+}
+
+func TestApplyDiff(t *testing.T) {
+	old := createListForTest(1, 5000)
+	new := old.Delete(10).Insert(2000, val(0)).Set(4000, val(0)).AppendList(createListForTest(1, 100))
+	patch := CreatePatch(Diff(old, new, func(a, b string) bool { return a == b }), new)
+	actual, err := old.Apply(patch)
+	if err != nil {
+		t.Error(err)
+	} else {
+		validateList3(t, actual, new.Slice(0, new.Size()))
+	}
+}
+
+func TestApplyInvalidHunks(t *testing.T) {
+	list := createListForTest(1, 100)
+	invalid := [][]Hunk[string]{
+		{{Offset: -1, Limit: 0}},
+		{{Offset: 5, Limit: 4}},
+		{{Offset: 90, Limit: 101}},
+		{{Offset: 10, Limit: 20}, {Offset: 15, Limit: 30}},
+	}
+	for _, hunks := range invalid {
+		if _, err := list.Apply(Patch[string]{Hunks: hunks}); err == nil {
+			t.Error("expected error for invalid hunks")
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package immutableList
+
+import "sort"
+
+// The search functions require a list sorted in ascending order relative to
+// the target of cmp.  cmp returns a negative number for values before the
+// target, zero for values matching it and a positive number for values after
+// it.  Each branch is decided by comparing against the last value of its
+// left child so a search makes O(log n) calls to cmp.
+
+// Search returns the index of the first value matching the target and true,
+// or the index at which the target would be inserted and false.
+func (this *listImpl[T]) Search(cmp func(T) int) (int, bool) {
+	index := this.LowerBound(cmp)
+	return index, index < this.Size() && cmp(this.root.get(index)) == 0
+}
+
+// LowerBound returns the index of the first value not before the target.
+func (this *listImpl[T]) LowerBound(cmp func(T) int) int {
+	return this.root.search(func(value T) bool {
+		return cmp(value) >= 0
+	})
+}
+
+// UpperBound returns the index of the first value after the target.
+func (this *listImpl[T]) UpperBound(cmp func(T) int) int {
+	return this.root.search(func(value T) bool {
+		return cmp(value) > 0
+	})
+}
+
+func (a *leafNode[T]) search(pred func(T) bool) int {
+	return sort.Search(len(a.values), func(i int) bool {
+		return pred(a.values[i])
+	})
+}
+
+func (e *emptyNode[T]) search(pred func(T) bool) int {
+	return 0
+}
+
+func (b *branchNode[T]) search(pred func(T) bool) int {
+	if pred(b.leftChild.getLast()) {
+		return b.leftChild.search(pred)
+	} else {
+		return b.leftChild.size() + b.rightChild.search(pred)
+	}
+}
@@ -0,0 +1,39 @@
+package immutableList
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSearch(t *testing.T) {
+	for _, length := range []int{0, 1, 31, 32, 33, 500, 2000} {
+		values := make([]int, length)
+		for i := range values {
+			values[i] = 2 * (i / 3)
+		}
+		list := FromSlice(values)
+		for target := -1; target <= 2*(length/3)+2; target++ {
+			cmp := func(value int) int { return value - target }
+			expectedLower, expectedUpper := length, length
+			for i := length - 1; i >= 0; i-- {
+				if values[i] >= target {
+					expectedLower = i
+				}
+				if values[i] > target {
+					expectedUpper = i
+				}
+			}
+			if actual := list.LowerBound(cmp); actual != expectedLower {
+				t.Error(fmt.Sprintf("LowerBound(%d) expected %d but got %d", target, expectedLower, actual))
+			}
+			if actual := list.UpperBound(cmp); actual != expectedUpper {
+				t.Error(fmt.Sprintf("UpperBound(%d) expected %d but got %d", target, expectedUpper, actual))
+			}
+			index, found := list.Search(cmp)
+			expectedFound := expectedLower < length && values[expectedLower] == target
+			if index != expectedLower || found != expectedFound {
+				t.Error(fmt.Sprintf("Search(%d) expected %d/%v but got %d/%v", target, expectedLower, expectedFound, index, found))
+			}
+		}
+	}
+}
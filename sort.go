@@ -0,0 +1,97 @@
+package immutableList
+
+import "sort"
+
+// Sort returns a list containing the same values ordered by less.
+// Leaves and subtrees that are already in order are reused as is, so
+// sorting a mostly sorted list copies little more than the values
+// that actually move.
+func (this *listImpl[T]) Sort(less func(a, b T) bool) List[T] {
+	return createListNode(this.config, this.root.sort(less, false, this.config.leafCapacity))
+}
+
+// SortStable is like Sort but keeps equal values in their original order.
+func (this *listImpl[T]) SortStable(less func(a, b T) bool) List[T] {
+	return createListNode(this.config, this.root.sort(less, true, this.config.leafCapacity))
+}
+
+func (a *leafNode[T]) sort(less func(a, b T) bool, stable bool, capacity int) node[T] {
+	if isSortedSlice(a.values, less) {
+		return a
+	}
+	values := make([]T, len(a.values))
+	copy(values, a.values)
+	if stable {
+		sort.SliceStable(values, func(i, j int) bool { return less(values[i], values[j]) })
+	} else {
+		sort.Slice(values, func(i, j int) bool { return less(values[i], values[j]) })
+	}
+	return createMultiValueLeafNode(values)
+}
+
+func (e *emptyNode[T]) sort(less func(a, b T) bool, stable bool, capacity int) node[T] {
+	return e
+}
+
+func (b *branchNode[T]) sort(less func(a, b T) bool, stable bool, capacity int) node[T] {
+	left := b.leftChild.sort(less, stable, capacity)
+	right := b.rightChild.sort(less, stable, capacity)
+	if !less(right.getFirst(), left.getLast()) {
+		if left == b.leftChild && right == b.rightChild {
+			return b
+		}
+		return appendNodes(left, right, capacity)
+	}
+	return mergeSortedNodes(left, right, less, capacity)
+}
+
+// Values at the start of left that are not greater than the first value of
+// right and values at the end of right that are not less than the last value
+// of left are already in their final position.  Only the overlapping values
+// in between are merged into new leaves.
+func mergeSortedNodes[T any](left node[T], right node[T], less func(a, b T) bool, capacity int) node[T] {
+	rightFirst := right.getFirst()
+	leftLimit := sort.Search(left.size(), func(i int) bool {
+		return less(rightFirst, left.get(i))
+	})
+	leftLast := left.getLast()
+	rightLimit := sort.Search(right.size(), func(i int) bool {
+		return !less(right.get(i), leftLast)
+	})
+
+	leftValues := sliceNode(left, leftLimit, left.size())
+	rightValues := sliceNode(right, 0, rightLimit)
+	merged := make([]T, 0, len(leftValues)+len(rightValues))
+	for len(leftValues) > 0 && len(rightValues) > 0 {
+		if less(rightValues[0], leftValues[0]) {
+			merged = append(merged, rightValues[0])
+			rightValues = rightValues[1:]
+		} else {
+			merged = append(merged, leftValues[0])
+			leftValues = leftValues[1:]
+		}
+	}
+	merged = append(merged, leftValues...)
+	merged = append(merged, rightValues...)
+
+	middle := appendNodes(left.head(leftLimit, capacity), createNodeFromSlice(merged, capacity), capacity)
+	return appendNodes(middle, right.tail(rightLimit, capacity), capacity)
+}
+
+func sliceNode[T any](n node[T], offset int, limit int) []T {
+	answer := make([]T, 0, limit-offset)
+	n.visit(0, offset, limit, func(_ int, value T) bool {
+		answer = append(answer, value)
+		return false
+	})
+	return answer
+}
+
+func isSortedSlice[T any](values []T, less func(a, b T) bool) bool {
+	for i := 1; i < len(values); i++ {
+		if less(values[i], values[i-1]) {
+			return false
+		}
+	}
+	return true
+}
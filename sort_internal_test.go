@@ -0,0 +1,71 @@
+package immutableList
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	for _, length := range []int{0, 1, 2, 31, 32, 33, 100, 1000, 5000} {
+		values := rand.Perm(length)
+		list := FromSlice(values)
+		sorted := list.Sort(func(a, b int) bool { return a < b })
+		validateSortedInts(t, sorted, length)
+		validateSortedInts(t, list.SortStable(func(a, b int) bool { return a < b }), length)
+		validateSortedInts(t, sorted.Sort(func(a, b int) bool { return a < b }), length)
+	}
+}
+
+func TestSortReusesSortedList(t *testing.T) {
+	list := createListForTestDirectly(1, 2000).Map(func(obj string) string {
+		i, _ := strconv.Atoi(obj)
+		return val(100000 + i)
+	})
+	sorted := list.Sort(func(a, b string) bool { return a < b })
+	if sorted.(*listImpl[string]).root != list.(*listImpl[string]).root {
+		t.Error("expected sorted list to be reused")
+	}
+
+	changed := list.Set(1000, val(0))
+	resorted := changed.Sort(func(a, b string) bool { return a < b })
+	expected := append([]string{val(0)}, list.Slice(0, 1000)...)
+	expected = append(expected, list.Slice(1001, 2000)...)
+	validateList3(t, resorted, expected)
+}
+
+func TestSortStable(t *testing.T) {
+	type pair struct {
+		key   int
+		order int
+	}
+	values := make([]pair, 3000)
+	for i := range values {
+		values[i] = pair{key: rand.Intn(20), order: i}
+	}
+	list := FromSlice(values).SortStable(func(a, b pair) bool { return a.key < b.key })
+	expected := append([]pair{}, values...)
+	sort.SliceStable(expected, func(i, j int) bool { return expected[i].key < expected[j].key })
+	for i, value := range list.Slice(0, list.Size()) {
+		if value != expected[i] {
+			t.Error(fmt.Sprintf("stable sort expected %v at %d but got %v", expected[i], i, value))
+		}
+	}
+	list.checkInvariants(func(message string) {
+		t.Error(message)
+	})
+}
+
+func validateSortedInts(t *testing.T, list List[int], size int) {
+	validateSize(t, list.Size(), size)
+	for i := 0; i < size; i++ {
+		if list.Get(i) != i {
+			t.Error(fmt.Sprintf("sort expected %d at %d but got %d", i, i, list.Get(i)))
+		}
+	}
+	list.checkInvariants(func(message string) {
+		t.Error(message)
+	})
+}
@@ -0,0 +1,139 @@
+package immutableList
+
+import "unsafe"
+
+// Stats describes the shape of the tree behind a List.
+type Stats struct {
+	Size        int
+	Depth       int
+	BranchCount int
+	LeafCount   int
+	// LeafFill[n] is the number of leaves holding exactly n values.  It has
+	// one entry more than the leaf capacity of the list.
+	LeafFill []int
+	// EstimatedBytes approximates the heap used by the nodes of the list.
+	// Memory referenced by the values themselves, such as the bytes of a
+	// string, is not included.
+	EstimatedBytes int
+}
+
+// Stats walks the tree and reports its depth, node counts, leaf fill levels
+// and estimated memory use.  Nodes appearing more than once in the tree are
+// only counted once.
+func (this *listImpl[T]) Stats() Stats {
+	answer := Stats{
+		Size:     this.Size(),
+		Depth:    this.root.depth(),
+		LeafFill: make([]int, this.config.leafCapacity+1),
+	}
+	visited := make(map[node[T]]bool)
+	collectStats(this.root, &answer, visited)
+	return answer
+}
+
+func collectStats[T any](n node[T], stats *Stats, visited map[node[T]]bool) {
+	if n.size() == 0 || visited[n] {
+		return
+	}
+	visited[n] = true
+	stats.EstimatedBytes += estimateNodeBytes(n)
+	if n.depth() > 0 {
+		stats.BranchCount++
+		collectStats(n.left(), stats, visited)
+		collectStats(n.right(), stats, visited)
+	} else {
+		stats.LeafCount++
+		stats.LeafFill[n.size()]++
+	}
+}
+
+func estimateNodeBytes[T any](n node[T]) int {
+	if stored, matches := n.(*storedNode[T]); matches {
+		return int(unsafe.Sizeof(*stored)) + estimateNodeBytes(stored.load())
+	}
+	if leaf, matches := n.(*leafNode[T]); matches {
+		var value T
+		return int(unsafe.Sizeof(*leaf)) + cap(leaf.values)*int(unsafe.Sizeof(value))
+	}
+	if branch, matches := n.(*branchNode[T]); matches {
+		return int(unsafe.Sizeof(*branch))
+	}
+	return 0
+}
+
+// SharingStats describes how much of their structure several lists share.
+type SharingStats struct {
+	// DistinctNodes counts the nodes reachable from at least one list and
+	// SharedNodes those reachable from more than one.
+	DistinctNodes int
+	SharedNodes   int
+	// DistinctLeafSlots counts the values held by distinct leaves and
+	// SharedLeafSlots those held by leaves reachable from more than one list.
+	DistinctLeafSlots int
+	SharedLeafSlots   int
+	// TotalBytes estimates the heap used by all distinct nodes together.
+	TotalBytes int
+	// UniqueBytes[i] estimates the heap used by nodes reachable only from
+	// lists[i], which is the memory released if only that list is dropped.
+	UniqueBytes []int
+}
+
+const sharedByMany = -1
+
+// MeasureSharing finds the nodes of lists by pointer identity and reports
+// which of them are shared.  Each node is visited at most twice no matter
+// how many of the lists contain it.
+func MeasureSharing[T any](lists ...List[T]) SharingStats {
+	owners := make(map[node[T]]int)
+	for i, list := range lists {
+		collectOwners(list.(*listImpl[T]).root, i, owners)
+	}
+	answer := SharingStats{UniqueBytes: make([]int, len(lists))}
+	for n, owner := range owners {
+		bytes := estimateNodeBytes(n)
+		answer.DistinctNodes++
+		answer.TotalBytes += bytes
+		if n.depth() == 0 {
+			answer.DistinctLeafSlots += n.size()
+		}
+		if owner == sharedByMany {
+			answer.SharedNodes++
+			if n.depth() == 0 {
+				answer.SharedLeafSlots += n.size()
+			}
+		} else {
+			answer.UniqueBytes[owner] += bytes
+		}
+	}
+	return answer
+}
+
+// Records owner as the list reaching each node.  A node already reached by
+// another list is marked as shared together with all of its descendants,
+// since they are reachable from both lists as well.
+func collectOwners[T any](n node[T], owner int, owners map[node[T]]int) {
+	if n.size() == 0 {
+		return
+	}
+	previous, found := owners[n]
+	if !found {
+		owners[n] = owner
+		if n.depth() > 0 {
+			collectOwners(n.left(), owner, owners)
+			collectOwners(n.right(), owner, owners)
+		}
+	} else if previous != owner && previous != sharedByMany {
+		markShared(n, owners)
+	}
+}
+
+func markShared[T any](n node[T], owners map[node[T]]int) {
+	if owners[n] == sharedByMany {
+		return
+	}
+	owners[n] = sharedByMany
+	if n.depth() > 0 {
+		markShared(n.left(), owners)
+		markShared(n.right(), owners)
+	}
+}
@@ -0,0 +1,59 @@
+package immutableList
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestStats(t *testing.T) {
+	stats := createListForTest(1, 1000).Stats()
+	validateSize(t, stats.Size, 1000)
+	validateSize(t, stats.Depth, 5)
+	validateSize(t, stats.LeafCount, 32)
+	validateSize(t, stats.BranchCount, 31)
+	validateSize(t, stats.LeafFill[defaultLeafCapacity], 31)
+	validateSize(t, stats.LeafFill[8], 1)
+	if stats.EstimatedBytes < 1000*int(unsafe.Sizeof("")) {
+		t.Error("expected estimate to include leaf values")
+	}
+
+	list := createListForTest(1, 100)
+	for i := 0; i < 50; i++ {
+		list = list.Delete(i)
+	}
+	stats = list.Stats()
+	total := 0
+	for fill, count := range stats.LeafFill {
+		total += fill * count
+	}
+	validateSize(t, total, 50)
+
+	doubled := list.AppendList(list).Stats()
+	validateSize(t, doubled.LeafCount, stats.LeafCount)
+	validateSize(t, doubled.BranchCount, stats.BranchCount+1)
+
+	empty := Create[string]().Stats()
+	validateSize(t, empty.LeafCount+empty.BranchCount+empty.EstimatedBytes, 0)
+}
+
+func TestMeasureSharing(t *testing.T) {
+	list := createListForTest(1, 1000)
+	changed := list.Set(0, val(0))
+	sharing := MeasureSharing(list, changed)
+	listStats, changedStats := list.Stats(), changed.Stats()
+
+	validateSize(t, sharing.DistinctNodes, listStats.LeafCount+listStats.BranchCount+6)
+	validateSize(t, sharing.SharedNodes, listStats.LeafCount+listStats.BranchCount-6)
+	validateSize(t, sharing.DistinctLeafSlots, 1000+defaultLeafCapacity)
+	validateSize(t, sharing.SharedLeafSlots, 1000-defaultLeafCapacity)
+	validateSize(t, sharing.TotalBytes, listStats.EstimatedBytes+sharing.UniqueBytes[1])
+	validateSize(t, sharing.UniqueBytes[0], sharing.UniqueBytes[1])
+	if sharing.UniqueBytes[0] <= 0 || sharing.UniqueBytes[0] >= changedStats.EstimatedBytes/10 {
+		t.Error("expected unique bytes to cover only the copied path")
+	}
+
+	unrelated := MeasureSharing(list, createListForTest(1, 1000), list)
+	validateSize(t, unrelated.SharedNodes, listStats.LeafCount+listStats.BranchCount)
+	validateSize(t, unrelated.UniqueBytes[0], 0)
+	validateSize(t, unrelated.UniqueBytes[1], listStats.EstimatedBytes)
+}
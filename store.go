@@ -0,0 +1,467 @@
+package immutableList
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+)
+
+// NodeKey identifies a stored node by the SHA-256 hash of its encoded form.
+// The zero NodeKey denotes an empty list.
+type NodeKey [sha256.Size]byte
+
+func (k NodeKey) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// NodeStore holds encoded nodes keyed by their content hash.  Since a key is
+// derived from the data stored under it, storing the same key twice is
+// harmless and stored nodes never change.
+type NodeStore interface {
+	Has(key NodeKey) (bool, error)
+	Get(key NodeKey) ([]byte, error)
+	Put(key NodeKey, data []byte) error
+}
+
+// Stored nodes are encoded as
+//
+//	leafNodeTag   valueCount uvarint, values written by the Codec
+//	branchNodeTag left ref, right ref
+//
+// where a ref is the child's 32 byte key followed by its size and depth as
+// uvarints so that a branch can be rebuilt without loading its children.
+
+// SaveList writes every node of list that is not already in store and
+// returns the key of its root.  Nodes loaded from store with the list's
+// codec are not written again, so saving an edited copy of a loaded list
+// only writes the nodes created by the edits.  The list must have been created with a codec.
+func SaveList[T any](store NodeStore, list List[T]) (NodeKey, error) {
+	impl := list.(*listImpl[T])
+	if impl.config.codec == nil {
+		return NodeKey{}, ErrNoCodec
+	}
+	if impl.root.size() == 0 {
+		return NodeKey{}, nil
+	}
+	return saveNode(store, impl.root, impl.config.codec)
+}
+
+func saveNode[T any](store NodeStore, n node[T], codec Codec[T]) (NodeKey, error) {
+	if stored, matches := n.(*storedNode[T]); matches {
+		if sameNodeStore(stored.source.store, store) && sameCodec(stored.source.codec, codec) {
+			return stored.key, nil
+		}
+		n = stored.load()
+	}
+	var buffer bytes.Buffer
+	if n.depth() > 0 {
+		buffer.WriteByte(branchNodeTag)
+		for _, child := range []node[T]{n.left(), n.right()} {
+			key, err := saveNode(store, child, codec)
+			if err != nil {
+				return NodeKey{}, err
+			}
+			buffer.Write(key[:])
+			writeUvarint(&buffer, uint64(child.size()))
+			writeUvarint(&buffer, uint64(child.depth()))
+		}
+	} else {
+		buffer.WriteByte(leafNodeTag)
+		writeUvarint(&buffer, uint64(n.size()))
+		var err error
+		n.visit(0, 0, n.size(), func(_ int, value T) bool {
+			err = codec.Encode(&buffer, value)
+			return err != nil
+		})
+		if err != nil {
+			return NodeKey{}, err
+		}
+	}
+	key := NodeKey(sha256.Sum256(buffer.Bytes()))
+	exists, err := store.Has(key)
+	if err == nil && !exists {
+		err = store.Put(key, buffer.Bytes())
+	}
+	return key, err
+}
+
+// A node loaded from another store, or encoded by another codec, has to be
+// written again since its key is unknown to store or its bytes would not
+// decode with codec.  Stores of a type that cannot be compared are never
+// the same.
+func sameNodeStore(a NodeStore, b NodeStore) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// LoadList returns the list saved in store under key.  Only the root node
+// is read immediately.  Other nodes are read the first time an operation
+// reaches them and are kept in memory for as long as the list is.  Because
+// List methods cannot return errors a failure to read a node at that point
+// causes a panic.  opts must include WithCodec with a Codec for T.  Leaves
+// holding more values than the leaf capacity in opts are rejected.
+func LoadList[T any](store NodeStore, key NodeKey, opts ...Option) (List[T], error) {
+	config := createListConfig[T](opts)
+	if config.codec == nil {
+		return nil, ErrNoCodec
+	}
+	if key == (NodeKey{}) {
+		return createListNode(config, createEmptyLeafNode[T]()), nil
+	}
+	root, err := loadNode(&nodeSource[T]{store: store, codec: config.codec, leafCapacity: config.leafCapacity}, key)
+	if err != nil {
+		return nil, err
+	}
+	return createListNode(config, root), nil
+}
+
+type nodeSource[T any] struct {
+	store        NodeStore
+	codec        Codec[T]
+	leafCapacity int
+}
+
+func loadNode[T any](source *nodeSource[T], key NodeKey) (node[T], error) {
+	data, err := source.store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if NodeKey(sha256.Sum256(data)) != key {
+		return nil, fmt.Errorf("stored node does not match key: %v", key)
+	}
+	reader := bytes.NewReader(data)
+	tag, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case leafNodeTag:
+		return readLeafNode(reader, source.codec, source.leafCapacity)
+	case branchNodeTag:
+		left, err := readStoredNodeRef(reader, source)
+		if err != nil {
+			return nil, err
+		}
+		right, err := readStoredNodeRef(reader, source)
+		if err != nil {
+			return nil, err
+		}
+		if depthDiff[T](left, right) > 1 {
+			return nil, fmt.Errorf("invalid child depths: leftDepth=%d rightDepth=%d", left.depth(), right.depth())
+		}
+		if left.size() > math.MaxInt-right.size() {
+			return nil, fmt.Errorf("invalid child sizes: leftSize=%d rightSize=%d", left.size(), right.size())
+		}
+		return createBranchNode[T](left, right), nil
+	default:
+		return nil, fmt.Errorf("invalid node tag: %d", tag)
+	}
+}
+
+func readStoredNodeRef[T any](reader *bytes.Reader, source *nodeSource[T]) (*storedNode[T], error) {
+	answer := &storedNode[T]{source: source}
+	if _, err := io.ReadFull(reader, answer.key[:]); err != nil {
+		return nil, err
+	}
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	depth, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if size < 1 || size > math.MaxInt {
+		return nil, fmt.Errorf("invalid child size: %d", size)
+	}
+	if depth > math.MaxInt {
+		return nil, fmt.Errorf("invalid child depth: %d", depth)
+	}
+	answer.mySize, answer.myDepth = int(size), int(depth)
+	return answer, nil
+}
+
+// storedNode stands in for a node that has not been read from a NodeStore
+// yet.  Size and depth are known from the parent so balancing never needs
+// to read a node.  Every other operation reads the node once and delegates
+// to it.
+type storedNode[T any] struct {
+	source  *nodeSource[T]
+	key     NodeKey
+	mySize  int
+	myDepth int
+	loaded  atomic.Pointer[loadedNode[T]]
+}
+
+type loadedNode[T any] struct {
+	node node[T]
+}
+
+func (s *storedNode[T]) load() node[T] {
+	if loaded := s.loaded.Load(); loaded != nil {
+		return loaded.node
+	}
+	n, err := loadNode(s.source, s.key)
+	if err == nil && (n.size() != s.mySize || n.depth() != s.myDepth) {
+		err = fmt.Errorf("node does not match its parent: size=%d depth=%d expectedSize=%d expectedDepth=%d", n.size(), n.depth(), s.mySize, s.myDepth)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("unable to load node %v: %v", s.key, err))
+	}
+	// goroutines loading at the same time all use the first node stored so
+	// the node keeps a single identity for Equal, Diff and MeasureSharing
+	if !s.loaded.CompareAndSwap(nil, &loadedNode[T]{node: n}) {
+		return s.loaded.Load().node
+	}
+	return n
+}
+
+func (s *storedNode[T]) size() int {
+	return s.mySize
+}
+
+func (s *storedNode[T]) depth() int {
+	return s.myDepth
+}
+
+func (s *storedNode[T]) get(index int) T {
+	return s.load().get(index)
+}
+
+func (s *storedNode[T]) getFirst() T {
+	return s.load().getFirst()
+}
+
+func (s *storedNode[T]) getLast() T {
+	return s.load().getLast()
+}
+
+func (s *storedNode[T]) append(value T, capacity int) node[T] {
+	return s.load().append(value, capacity)
+}
+
+func (s *storedNode[T]) prepend(value T, capacity int) node[T] {
+	return s.load().prepend(value, capacity)
+}
+
+func (s *storedNode[T]) appendNode(n node[T], capacity int) node[T] {
+	return s.load().appendNode(n, capacity)
+}
+
+func (s *storedNode[T]) prependNode(n node[T], capacity int) node[T] {
+	return s.load().prependNode(n, capacity)
+}
+
+func (s *storedNode[T]) insert(index int, value T, capacity int) node[T] {
+	return s.load().insert(index, value, capacity)
+}
+
+func (s *storedNode[T]) delete(index int) node[T] {
+	return s.load().delete(index)
+}
+
+func (s *storedNode[T]) set(index int, value T) node[T] {
+	return s.load().set(index, value)
+}
+
+func (s *storedNode[T]) head(index int, capacity int) node[T] {
+	if index == s.mySize {
+		return s
+	}
+	return s.load().head(index, capacity)
+}
+
+func (s *storedNode[T]) tail(index int, capacity int) node[T] {
+	if index == 0 {
+		return s
+	}
+	return s.load().tail(index, capacity)
+}
+
+func (s *storedNode[T]) pop() (T, node[T]) {
+	return s.load().pop()
+}
+
+func (s *storedNode[T]) forEach(proc Processor[T]) {
+	s.load().forEach(proc)
+}
+
+func (s *storedNode[T]) mapValues(mapper func(T) T) node[T] {
+	return s.load().mapValues(mapper)
+}
+
+func (s *storedNode[T]) sort(less func(a, b T) bool, stable bool, capacity int) node[T] {
+	sorted := s.load().sort(less, stable, capacity)
+	if sorted == s.load() {
+		return s
+	}
+	return sorted
+}
+
+func (s *storedNode[T]) search(pred func(T) bool) int {
+	return s.load().search(pred)
+}
+
+func (s *storedNode[T]) contentHash(config *listConfig[T]) *nodeHash[T] {
+	return s.load().contentHash(config)
+}
+
+func (s *storedNode[T]) visit(base int, start int, limit int, v VisitProc[T]) bool {
+	return s.load().visit(base, start, limit, v)
+}
+
+func (s *storedNode[T]) all(base int, yield func(int, T) bool) bool {
+	return s.load().all(base, yield)
+}
+
+func (s *storedNode[T]) backward(base int, yield func(int, T) bool) bool {
+	return s.load().backward(base, yield)
+}
+
+func (s *storedNode[T]) checkInvariants(report reporter, isRoot bool, capacity int) {
+	loaded := s.load()
+	if loaded.size() != s.mySize || loaded.depth() != s.myDepth {
+		report(fmt.Sprintf("stored node mismatch: size=%d depth=%d loadedSize=%d loadedDepth=%d", s.mySize, s.myDepth, loaded.size(), loaded.depth()))
+	}
+	loaded.checkInvariants(report, isRoot, capacity)
+}
+
+func (s *storedNode[T]) rotateLeft(parentLeft node[T]) node[T] {
+	return s.load().rotateLeft(parentLeft)
+}
+
+func (s *storedNode[T]) rotateRight(parentRight node[T]) node[T] {
+	return s.load().rotateRight(parentRight)
+}
+
+func (s *storedNode[T]) next(state *iteratorState[T]) (*iteratorState[T], T) {
+	return s.load().next(state)
+}
+
+func (s *storedNode[T]) prev(state *iteratorState[T]) (*iteratorState[T], T) {
+	return s.load().prev(state)
+}
+
+func (s *storedNode[T]) seek(state *iteratorState[T], index int) *iteratorState[T] {
+	return s.load().seek(state, index)
+}
+
+func (s *storedNode[T]) seekRev(state *iteratorState[T], index int) *iteratorState[T] {
+	return s.load().seekRev(state, index)
+}
+
+func (s *storedNode[T]) ownedSet(owner *transientOwner, index int, value T) node[T] {
+	return s.load().ownedSet(owner, index, value)
+}
+
+func (s *storedNode[T]) ownedAppend(owner *transientOwner, value T, capacity int) node[T] {
+	return s.load().ownedAppend(owner, value, capacity)
+}
+
+func (s *storedNode[T]) ownedInsert(owner *transientOwner, index int, value T, capacity int) node[T] {
+	return s.load().ownedInsert(owner, index, value, capacity)
+}
+
+func (s *storedNode[T]) ownedDelete(owner *transientOwner, index int) node[T] {
+	return s.load().ownedDelete(owner, index)
+}
+
+func (s *storedNode[T]) left() node[T] {
+	return s.load().left()
+}
+
+func (s *storedNode[T]) right() node[T] {
+	return s.load().right()
+}
+
+// FileNodeStore keeps each node in its own file below a directory, using
+// the first two hex digits of the key as a subdirectory.
+type FileNodeStore struct {
+	dir string
+}
+
+func OpenFileNodeStore(dir string) (*FileNodeStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileNodeStore{dir: dir}, nil
+}
+
+func (this *FileNodeStore) path(key NodeKey) string {
+	name := key.String()
+	return filepath.Join(this.dir, name[0:2], name)
+}
+
+func (this *FileNodeStore) Has(key NodeKey) (bool, error) {
+	_, err := os.Stat(this.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (this *FileNodeStore) Get(key NodeKey) ([]byte, error) {
+	return os.ReadFile(this.path(key))
+}
+
+// Put writes to a temporary file and renames it so that a partially written
+// node is never visible under its key.  The file and the directories leading
+// to it are synced before Put returns so the node survives a crash.
+func (this *FileNodeStore) Put(key NodeKey, data []byte) error {
+	path := this.path(key)
+	dir := filepath.Dir(path)
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		if err := syncDir(this.dir); err != nil {
+			return err
+		}
+	}
+	temp, err := os.CreateTemp(dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	return syncDir(dir)
+}
+
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = file.Sync()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
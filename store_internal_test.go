@@ -0,0 +1,304 @@
+package immutableList
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingNodeStore struct {
+	store NodeStore
+	gets  int
+	puts  int
+}
+
+func (this *countingNodeStore) Has(key NodeKey) (bool, error) {
+	return this.store.Has(key)
+}
+
+func (this *countingNodeStore) Get(key NodeKey) ([]byte, error) {
+	this.gets++
+	return this.store.Get(key)
+}
+
+func (this *countingNodeStore) Put(key NodeKey, data []byte) error {
+	this.puts++
+	return this.store.Put(key, data)
+}
+
+// memoryNodeStore keeps tests that save large lists from writing and syncing
+// thousands of files
+type memoryNodeStore struct {
+	lock  sync.Mutex
+	nodes map[NodeKey][]byte
+}
+
+func createMemoryNodeStore() *memoryNodeStore {
+	return &memoryNodeStore{nodes: make(map[NodeKey][]byte)}
+}
+
+func (this *memoryNodeStore) Has(key NodeKey) (bool, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	_, found := this.nodes[key]
+	return found, nil
+}
+
+func (this *memoryNodeStore) Get(key NodeKey) ([]byte, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	data, found := this.nodes[key]
+	if !found {
+		return nil, fmt.Errorf("missing node: %v", key)
+	}
+	return data, nil
+}
+
+func (this *memoryNodeStore) Put(key NodeKey, data []byte) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.nodes[key] = data
+	return nil
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	fileStore, err := OpenFileNodeStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := WithCodec[string](StringCodec{})
+	for _, length := range []int{0, 1, 32, 33, 100, 5000} {
+		var store NodeStore = fileStore
+		if length > 100 {
+			store = createMemoryNodeStore()
+		}
+		list := FromSlice(createListForTest(1, length).Slice(0, length), codec)
+		key, err := SaveList(store, list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadList[string](store, key, codec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		validateList(t, loaded, length)
+	}
+}
+
+func TestFileNodeStoreLeavesNoTemporaryFiles(t *testing.T) {
+	dir := t.TempDir()
+	fileStore, err := OpenFileNodeStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := FromSlice(createListForTest(1, 100).Slice(0, 100), WithCodec[string](StringCodec{}))
+	if _, err := SaveList(fileStore, list); err != nil {
+		t.Fatal(err)
+	}
+	temporary, err := filepath.Glob(filepath.Join(dir, "*", "tmp-*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateSize(t, len(temporary), 0)
+}
+
+func TestStoreLoadsLazily(t *testing.T) {
+	store := &countingNodeStore{store: createMemoryNodeStore()}
+	codec := WithCodec[string](StringCodec{})
+	list := FromSlice(createListForTest(1, 100000).Slice(0, 100000), codec)
+	key, err := SaveList(store, list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depth := list.(*listImpl[string]).root.depth()
+
+	store.gets = 0
+	loaded, err := LoadList[string](store, key, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateValue(t, 54321, loaded.Get(54320))
+	if store.gets > depth+1 {
+		t.Error(fmt.Sprintf("expected at most %d nodes to be read but read %d", depth+1, store.gets))
+	}
+
+	store.puts = 0
+	edited := loaded.Set(777, val(0)).Append(val(100001))
+	editedKey, err := SaveList(store, edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.puts > 4*(depth+1) {
+		t.Error(fmt.Sprintf("expected only edited nodes to be written but wrote %d", store.puts))
+	}
+
+	original, err := LoadList[string](store, key, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateList(t, original, 100000)
+	reloaded, err := LoadList[string](store, editedKey, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateList3(t, reloaded, edited.Slice(0, edited.Size()))
+}
+
+func TestStoreSaveLoadedListElsewhere(t *testing.T) {
+	codec := WithCodec[string](StringCodec{})
+	first, second := createMemoryNodeStore(), createMemoryNodeStore()
+	key, err := SaveList(first, FromSlice(createListForTest(1, 1000).Slice(0, 1000), codec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadList[string](first, key, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := loaded.Set(500, val(0))
+	editedKey, err := SaveList(second, edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := LoadList[string](second, editedKey, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateList3(t, reloaded, edited.Slice(0, edited.Size()))
+
+	little := WithCodec[int64](FixedSizeCodec[int64]{})
+	big := WithCodec[int64](FixedSizeCodec[int64]{Order: binary.BigEndian})
+	values := make([]int64, 100)
+	for i := range values {
+		values[i] = int64(i)
+	}
+	key, err = SaveList(first, FromSlice(values, little))
+	if err != nil {
+		t.Fatal(err)
+	}
+	numbers, err := LoadList[int64](first, key, little)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combined := FromSlice([]int64{-1}, big).AppendList(numbers)
+	key, err = SaveList(first, combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	numbers, err = LoadList[int64](first, key, big)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateSize(t, numbers.Size(), 101)
+	for i := 0; i < 101; i++ {
+		if numbers.Get(i) != int64(i-1) {
+			t.Error(fmt.Sprintf("unexpected value at %d: %d", i, numbers.Get(i)))
+		}
+	}
+}
+
+type slowNodeStore struct {
+	NodeStore
+}
+
+func (this slowNodeStore) Get(key NodeKey) ([]byte, error) {
+	time.Sleep(10 * time.Millisecond)
+	return this.NodeStore.Get(key)
+}
+
+func TestStoreConcurrentLoads(t *testing.T) {
+	store := createMemoryNodeStore()
+	codec := WithCodec[string](StringCodec{})
+	key, err := SaveList(store, FromSlice(createListForTest(1, 1000).Slice(0, 1000), codec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadList[string](slowNodeStore{store}, key, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	child := loaded.(*listImpl[string]).root.left().(*storedNode[string])
+	nodes := make([]node[string], 8)
+	var group sync.WaitGroup
+	for i := range nodes {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			nodes[i] = child.load()
+		}()
+	}
+	group.Wait()
+	for i := range nodes {
+		if nodes[i] != nodes[0] {
+			t.Error("expected concurrent loads to return the same node")
+		}
+	}
+}
+
+func TestStoreErrors(t *testing.T) {
+	fileStore, err := OpenFileNodeStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SaveList(fileStore, createListForTest(1, 10)); !errors.Is(err, ErrNoCodec) {
+		t.Error("expected ErrNoCodec for list without codec")
+	}
+	if _, err := LoadList[string](fileStore, NodeKey{1}); !errors.Is(err, ErrNoCodec) {
+		t.Error("expected ErrNoCodec loading without codec")
+	}
+	if _, err := LoadList[string](fileStore, NodeKey{1}, WithCodec[string](StringCodec{})); err == nil {
+		t.Error("expected error for missing node")
+	}
+}
+
+func TestStoreRejectsInvalidChildren(t *testing.T) {
+	store := createMemoryNodeStore()
+	codec := WithCodec[string](StringCodec{})
+	leafKey, err := SaveList(store, FromSlice([]string{"a"}, codec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	putBranch := func(leftSize uint64, leftDepth uint64, rightSize uint64, rightDepth uint64) NodeKey {
+		var buffer bytes.Buffer
+		buffer.WriteByte(branchNodeTag)
+		for _, ref := range [][2]uint64{{leftSize, leftDepth}, {rightSize, rightDepth}} {
+			buffer.Write(leafKey[:])
+			writeUvarint(&buffer, ref[0])
+			writeUvarint(&buffer, ref[1])
+		}
+		key := NodeKey(sha256.Sum256(buffer.Bytes()))
+		store.Put(key, buffer.Bytes())
+		return key
+	}
+
+	invalid := []NodeKey{
+		putBranch(0, 0, 1, 0),
+		putBranch(1, 0, 1<<63, 0),
+		putBranch(1, 0, 1, 1<<63),
+		putBranch(1, 0, 1, 2),
+		putBranch(math.MaxInt, 0, 1, 0),
+	}
+	for _, key := range invalid {
+		if _, err := LoadList[string](store, key, codec); err == nil {
+			t.Error(fmt.Sprintf("expected error for %v", key))
+		}
+	}
+
+	mismatched, err := LoadList[string](store, putBranch(1, 0, 5, 1), codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for child that does not match its parent")
+		}
+	}()
+	mismatched.Get(5)
+}
@@ -0,0 +1,256 @@
+package immutableList
+
+import "fmt"
+
+// Transient is a mutable view of a List intended for batches of edits.
+// Nodes created by a Transient are owned by it and are updated in place
+// by later edits, while nodes shared with the original List are copied
+// on first write.  A Transient must not be used after Persistent() has
+// been called or from more than one goroutine at a time.
+type Transient[T any] interface {
+	Size() int
+	Get(index int) T
+	Append(value T) Transient[T]
+	Insert(indexBefore int, value T) Transient[T]
+	Delete(index int) Transient[T]
+	Set(index int, value T) Transient[T]
+	Persistent() List[T]
+}
+
+// Every Transient allocates its own owner so pointer comparison identifies
+// the nodes it may modify.  The owner is disabled by Persistent().
+type transientOwner struct {
+	editable bool
+}
+
+type transientImpl[T any] struct {
+	owner  *transientOwner
+	root   node[T]
+	config *listConfig[T]
+}
+
+func (this *listImpl[T]) Transient() Transient[T] {
+	return &transientImpl[T]{owner: &transientOwner{editable: true}, root: this.root, config: this.config}
+}
+
+func (this *transientImpl[T]) ensureEditable() {
+	if !this.owner.editable {
+		panic("transient used after Persistent")
+	}
+}
+
+func (this *transientImpl[T]) Size() int {
+	this.ensureEditable()
+	return this.root.size()
+}
+
+func (this *transientImpl[T]) Get(index int) T {
+	this.ensureEditable()
+	return this.root.get(index)
+}
+
+func (this *transientImpl[T]) Append(value T) Transient[T] {
+	this.ensureEditable()
+	this.root = this.root.ownedAppend(this.owner, value, this.config.leafCapacity)
+	return this
+}
+
+func (this *transientImpl[T]) Insert(indexBefore int, value T) Transient[T] {
+	this.ensureEditable()
+	this.root = this.root.ownedInsert(this.owner, indexBefore, value, this.config.leafCapacity)
+	return this
+}
+
+func (this *transientImpl[T]) Delete(index int) Transient[T] {
+	this.ensureEditable()
+	this.root = this.root.ownedDelete(this.owner, index)
+	return this
+}
+
+func (this *transientImpl[T]) Set(index int, value T) Transient[T] {
+	this.ensureEditable()
+	if index == this.root.size() {
+		this.root = this.root.ownedAppend(this.owner, value, this.config.leafCapacity)
+	} else {
+		this.root = this.root.ownedSet(this.owner, index, value)
+	}
+	return this
+}
+
+// Persistent freezes the edited nodes into a List.  Because the owner is
+// disabled and never shared with a later Transient the nodes are never
+// modified again.
+func (this *transientImpl[T]) Persistent() List[T] {
+	this.ensureEditable()
+	this.owner.editable = false
+	answer := createListNode(this.config, this.root)
+	this.root = nil
+	return answer
+}
+
+func createOwnedLeafNode[T any](owner *transientOwner, value T, capacity int) *leafNode[T] {
+	values := make([]T, 1, capacity)
+	values[0] = value
+	return &leafNode[T]{values: values, owner: owner}
+}
+
+// reserve is the slice capacity given to a copied leaf so that in place
+// inserts up to the leaf capacity do not reallocate
+func (a *leafNode[T]) ownedLeaf(owner *transientOwner, reserve int) *leafNode[T] {
+	if a.owner == owner {
+		return a
+	}
+	values := make([]T, len(a.values), reserve)
+	copy(values, a.values)
+	return &leafNode[T]{values: values, owner: owner}
+}
+
+func (a *leafNode[T]) ownedSet(owner *transientOwner, index int, value T) node[T] {
+	if index < 0 || index >= len(a.values) {
+		panic(fmt.Sprintf("invalid index for leaf node: %d", index))
+	}
+	leaf := a.ownedLeaf(owner, len(a.values))
+	leaf.values[index] = value
+	return leaf
+}
+
+func (a *leafNode[T]) ownedAppend(owner *transientOwner, value T, capacity int) node[T] {
+	return a.ownedInsert(owner, len(a.values), value, capacity)
+}
+
+func (a *leafNode[T]) ownedInsert(owner *transientOwner, index int, value T, capacity int) node[T] {
+	currentSize := len(a.values)
+	if index < 0 || index > currentSize {
+		panic(fmt.Sprintf("invalid index for leaf node: %d", index))
+	}
+
+	if currentSize < capacity {
+		leaf := a.ownedLeaf(owner, capacity)
+		var zero T
+		leaf.values = append(leaf.values, zero)
+		copy(leaf.values[index+1:], leaf.values[index:currentSize])
+		leaf.values[index] = value
+		return leaf
+	} else if index == 0 {
+		return createOwnedBranchNode[T](owner, createOwnedLeafNode(owner, value, capacity), a)
+	} else if index == currentSize {
+		return createOwnedBranchNode[T](owner, a, createOwnedLeafNode(owner, value, capacity))
+	} else {
+		left := make([]T, index, capacity)
+		copy(left, a.values[0:index])
+
+		right := make([]T, currentSize+1-index, capacity)
+		right[0] = value
+		copy(right[1:], a.values[index:])
+		return createOwnedBranchNode[T](owner, &leafNode[T]{values: left, owner: owner}, &leafNode[T]{values: right, owner: owner})
+	}
+}
+
+func (a *leafNode[T]) ownedDelete(owner *transientOwner, index int) node[T] {
+	currentSize := len(a.values)
+	if index < 0 || index >= currentSize {
+		panic(fmt.Sprintf("invalid index for leaf node: %d", index))
+	}
+	if currentSize == 1 {
+		return createEmptyLeafNode[T]()
+	}
+	leaf := a.ownedLeaf(owner, len(a.values))
+	copy(leaf.values[index:], leaf.values[index+1:])
+	var zero T
+	leaf.values[currentSize-1] = zero
+	leaf.values = leaf.values[0 : currentSize-1]
+	return leaf
+}
+
+func (e *emptyNode[T]) ownedSet(owner *transientOwner, index int, value T) node[T] {
+	panic("not implemented for empty nodes")
+}
+
+func (e *emptyNode[T]) ownedAppend(owner *transientOwner, value T, capacity int) node[T] {
+	return createOwnedLeafNode(owner, value, capacity)
+}
+
+func (e *emptyNode[T]) ownedInsert(owner *transientOwner, index int, value T, capacity int) node[T] {
+	if index == 0 {
+		return createOwnedLeafNode(owner, value, capacity)
+	} else {
+		panic(fmt.Sprintf("invalid index for empty node: %d", index))
+	}
+}
+
+func (e *emptyNode[T]) ownedDelete(owner *transientOwner, index int) node[T] {
+	panic("not implemented for empty nodes")
+}
+
+func createOwnedBranchNode[T any](owner *transientOwner, leftChild node[T], rightChild node[T]) *branchNode[T] {
+	return &branchNode[T]{
+		leftChild:  leftChild,
+		rightChild: rightChild,
+		mySize:     leftChild.size() + rightChild.size(),
+		myDepth:    1 + maxDepth(leftChild, rightChild),
+		owner:      owner,
+	}
+}
+
+func (b *branchNode[T]) ownedBranch(owner *transientOwner) *branchNode[T] {
+	if b.owner == owner {
+		return b
+	}
+	return createOwnedBranchNode(owner, b.leftChild, b.rightChild)
+}
+
+// updates size and depth after a child was replaced in place, falling back
+// to the persistent rotations when the children are no longer balanced
+func (b *branchNode[T]) ownedRebalance() node[T] {
+	if depthDiff(b.leftChild, b.rightChild) > 1 {
+		return createBalancedBranchNode(b.leftChild, b.rightChild)
+	}
+	b.mySize = b.leftChild.size() + b.rightChild.size()
+	b.myDepth = 1 + maxDepth(b.leftChild, b.rightChild)
+	return b
+}
+
+func (b *branchNode[T]) ownedSet(owner *transientOwner, index int, value T) node[T] {
+	branch := b.ownedBranch(owner)
+	leftSize := branch.leftChild.size()
+	if index < leftSize {
+		branch.leftChild = branch.leftChild.ownedSet(owner, index, value)
+	} else {
+		branch.rightChild = branch.rightChild.ownedSet(owner, index-leftSize, value)
+	}
+	return branch
+}
+
+func (b *branchNode[T]) ownedAppend(owner *transientOwner, value T, capacity int) node[T] {
+	branch := b.ownedBranch(owner)
+	branch.rightChild = branch.rightChild.ownedAppend(owner, value, capacity)
+	return branch.ownedRebalance()
+}
+
+func (b *branchNode[T]) ownedInsert(owner *transientOwner, index int, value T, capacity int) node[T] {
+	branch := b.ownedBranch(owner)
+	leftSize := branch.leftChild.size()
+	if index < leftSize {
+		branch.leftChild = branch.leftChild.ownedInsert(owner, index, value, capacity)
+	} else {
+		branch.rightChild = branch.rightChild.ownedInsert(owner, index-leftSize, value, capacity)
+	}
+	return branch.ownedRebalance()
+}
+
+func (b *branchNode[T]) ownedDelete(owner *transientOwner, index int) node[T] {
+	branch := b.ownedBranch(owner)
+	leftSize := branch.leftChild.size()
+	if index < leftSize {
+		branch.leftChild = branch.leftChild.ownedDelete(owner, index)
+		if branch.leftChild.size() == 0 {
+			return branch.rightChild
+		}
+	} else {
+		branch.rightChild = branch.rightChild.ownedDelete(owner, index-leftSize)
+		if branch.rightChild.size() == 0 {
+			return branch.leftChild
+		}
+	}
+	return branch.ownedRebalance()
+}
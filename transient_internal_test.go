@@ -0,0 +1,89 @@
+package immutableList
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestTransientAppend(t *testing.T) {
+	original := createListForTest(1, 100)
+	transient := original.Transient()
+	for i := 101; i <= 2000; i++ {
+		transient.Append(val(i))
+	}
+	validateSize(t, transient.Size(), 2000)
+	validateList(t, transient.Persistent(), 2000)
+	validateList(t, original, 100)
+
+	transient = Create[string]().Transient()
+	for i := 1; i <= 1000; i++ {
+		transient.Append(val(i))
+	}
+	validateList(t, transient.Persistent(), 1000)
+}
+
+func TestTransientEdits(t *testing.T) {
+	original, expected := createListForTest(1, 700), make([]string, 700)
+	for i := range expected {
+		expected[i] = val(i + 1)
+	}
+	originalExpected := append([]string{}, expected...)
+	transient := original.Transient()
+	for loop := 0; loop < 3000; loop++ {
+		switch rand.Intn(3) {
+		case 0:
+			index := rand.Intn(len(expected) + 1)
+			value := val(loop + 10000)
+			transient.Insert(index, value)
+			expected = insertToSlice(expected, index, value)
+		case 1:
+			if len(expected) > 0 {
+				index := rand.Intn(len(expected))
+				transient.Delete(index)
+				expected = deleteFromSlice(expected, index)
+			}
+		default:
+			index := rand.Intn(len(expected) + 1)
+			value := val(loop + 20000)
+			transient.Set(index, value)
+			if index == len(expected) {
+				expected = append(expected, value)
+			} else {
+				expected[index] = value
+			}
+		}
+	}
+	for i := range expected {
+		if transient.Get(i) != expected[i] {
+			t.Error(fmt.Sprintf("get expected %v/%s but got %v/%s", i, expected[i], i, transient.Get(i)))
+		}
+	}
+	validateList3(t, transient.Persistent(), expected)
+	validateList3(t, original, originalExpected)
+}
+
+func TestTransientDeleteAll(t *testing.T) {
+	transient := createListForTest(1, 300).Transient()
+	for transient.Size() > 0 {
+		transient.Delete(transient.Size() / 2)
+	}
+	validateList(t, transient.Persistent(), 0)
+}
+
+func TestTransientPersistentIsFrozen(t *testing.T) {
+	transient := createListForTest(1, 64).Transient()
+	transient.Set(0, val(1))
+	frozen := transient.Persistent()
+
+	second := frozen.Transient()
+	second.Set(0, val(999))
+	validateList(t, frozen, 64)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when using transient after Persistent")
+		}
+	}()
+	transient.Append(val(65))
+}
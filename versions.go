@@ -0,0 +1,212 @@
+package immutableList
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// The versions format stores every distinct node once, children before their
+// parents, so nodes shared by several versions take no extra space.
+//
+//	nodeCount uvarint
+//	nodeCount times:
+//	  leafNodeTag   valueCount uvarint, values written by the Codec
+//	  branchNodeTag leftId uvarint, rightId uvarint
+//	versionCount uvarint
+//	versionCount times: rootId uvarint
+//
+// Node ids start at 1 in the order the nodes were written.  A root id of 0
+// denotes an empty list.
+const (
+	leafNodeTag   byte = 0
+	branchNodeTag byte = 1
+)
+
+// EncodeVersions writes versions to w, writing nodes shared by more than one
+// version or more than once within a version only once.  Every version must
+// have been created with the same codec.
+func EncodeVersions[T any](w io.Writer, versions []List[T]) (int64, error) {
+	roots := make([]node[T], len(versions))
+	var codec Codec[T]
+	for i, version := range versions {
+		impl := version.(*listImpl[T])
+		if impl.config.codec == nil {
+			return 0, ErrNoCodec
+		}
+		if i == 0 {
+			codec = impl.config.codec
+		} else if !sameCodec(codec, impl.config.codec) {
+			return 0, fmt.Errorf("version %d has a different codec: %T", i, impl.config.codec)
+		}
+		roots[i] = impl.root
+	}
+
+	ids := make(map[node[T]]uint64)
+	ordered := make([]node[T], 0)
+	for _, root := range roots {
+		ordered = collectVersionNodes(root, ids, ordered)
+	}
+
+	counter := &countingWriter{w: w}
+	if err := writeUvarint(counter, uint64(len(ordered))); err != nil {
+		return counter.count, err
+	}
+	for _, n := range ordered {
+		if err := writeVersionNode(counter, n, ids, codec); err != nil {
+			return counter.count, err
+		}
+	}
+	if err := writeUvarint(counter, uint64(len(roots))); err != nil {
+		return counter.count, err
+	}
+	for _, root := range roots {
+		if err := writeUvarint(counter, ids[root]); err != nil {
+			return counter.count, err
+		}
+	}
+	return counter.count, nil
+}
+
+func collectVersionNodes[T any](n node[T], ids map[node[T]]uint64, ordered []node[T]) []node[T] {
+	if n.size() == 0 {
+		return ordered
+	}
+	if _, found := ids[n]; found {
+		return ordered
+	}
+	if n.depth() > 0 {
+		ordered = collectVersionNodes(n.left(), ids, ordered)
+		ordered = collectVersionNodes(n.right(), ids, ordered)
+	}
+	ordered = append(ordered, n)
+	ids[n] = uint64(len(ordered))
+	return ordered
+}
+
+func writeVersionNode[T any](w io.Writer, n node[T], ids map[node[T]]uint64, codec Codec[T]) error {
+	if n.depth() > 0 {
+		if _, err := w.Write([]byte{branchNodeTag}); err != nil {
+			return err
+		}
+		if err := writeUvarint(w, ids[n.left()]); err != nil {
+			return err
+		}
+		return writeUvarint(w, ids[n.right()])
+	}
+	if _, err := w.Write([]byte{leafNodeTag}); err != nil {
+		return err
+	}
+	if err := writeUvarint(w, uint64(n.size())); err != nil {
+		return err
+	}
+	var err error
+	n.visit(0, 0, n.size(), func(_ int, value T) bool {
+		err = codec.Encode(w, value)
+		return err != nil
+	})
+	return err
+}
+
+// DecodeVersions reads lists written by EncodeVersions.  Nodes shared in the
+// encoded lists are shared by the decoded lists as well.  opts must include
+// WithCodec with a Codec for T.  Leaves holding more values than the leaf
+// capacity in opts are rejected.
+func DecodeVersions[T any](r io.Reader, opts ...Option) ([]List[T], error) {
+	config := createListConfig[T](opts)
+	if config.codec == nil {
+		return nil, ErrNoCodec
+	}
+	counter := &countingReader{r: r}
+	nodeCount, err := binary.ReadUvarint(counter)
+	if err != nil {
+		return nil, err
+	}
+	nodes := []node[T]{createEmptyLeafNode[T]()}
+	for id := uint64(1); id <= nodeCount; id++ {
+		n, err := readVersionNode(counter, nodes, config)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	versionCount, err := binary.ReadUvarint(counter)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]List[T], 0)
+	for i := uint64(0); i < versionCount; i++ {
+		id, err := binary.ReadUvarint(counter)
+		if err != nil {
+			return nil, err
+		}
+		if id >= uint64(len(nodes)) {
+			return nil, fmt.Errorf("invalid root id: %d", id)
+		}
+		versions = append(versions, createListNode(config, nodes[id]))
+	}
+	return versions, nil
+}
+
+// reads the value count and values that follow a leafNodeTag, rejecting
+// leaves holding more values than capacity
+func readLeafNode[T any](r io.Reader, codec Codec[T], capacity int) (node[T], error) {
+	count, err := binary.ReadUvarint(asByteReader(r))
+	if err != nil {
+		return nil, err
+	}
+	if count < 1 || count > uint64(capacity) {
+		return nil, fmt.Errorf("invalid leaf size: %d", count)
+	}
+	values := make([]T, count)
+	for i := range values {
+		if values[i], err = codec.Decode(r); err != nil {
+			return nil, err
+		}
+	}
+	return createMultiValueLeafNode(values), nil
+}
+
+// Codecs of a type that cannot be compared, such as a struct holding a func,
+// are never the same.  Such codecs can be shared through a pointer instead.
+func sameCodec[T any](a Codec[T], b Codec[T]) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+func readVersionNode[T any](r *countingReader, nodes []node[T], config *listConfig[T]) (node[T], error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case leafNodeTag:
+		return readLeafNode(r, config.codec, config.leafCapacity)
+	case branchNodeTag:
+		leftId, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		rightId, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		if leftId < 1 || leftId >= uint64(len(nodes)) || rightId < 1 || rightId >= uint64(len(nodes)) {
+			return nil, fmt.Errorf("invalid child ids: left=%d right=%d", leftId, rightId)
+		}
+		left, right := nodes[leftId], nodes[rightId]
+		if depthDiff(left, right) > 1 {
+			return nil, fmt.Errorf("invalid child depths: leftDepth=%d rightDepth=%d", left.depth(), right.depth())
+		}
+		if left.size() > math.MaxInt-right.size() {
+			return nil, fmt.Errorf("invalid child sizes: leftSize=%d rightSize=%d", left.size(), right.size())
+		}
+		return createBranchNode(left, right), nil
+	default:
+		return nil, fmt.Errorf("invalid node tag: %d", tag)
+	}
+}
@@ -0,0 +1,88 @@
+package immutableList
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestVersionsRoundTrip(t *testing.T) {
+	codec := WithCodec[string](StringCodec{})
+	base := FromSlice(createListForTest(1, 5000).Slice(0, 5000), codec)
+	versions := []List[string]{base}
+	for i := 0; i < 100; i++ {
+		versions = append(versions, versions[i].Set(i*37, val(-i)))
+	}
+	versions = append(versions, CreateWithOptions[string](codec), base.AppendList(base))
+
+	var buffer bytes.Buffer
+	if _, err := EncodeVersions(&buffer, versions); err != nil {
+		t.Fatal(err)
+	}
+	single, _ := base.MarshalBinary()
+	if buffer.Len() > 3*len(single) {
+		t.Error(fmt.Sprintf("expected shared nodes to be written once: versions=%d single=%d", buffer.Len(), len(single)))
+	}
+
+	decoded, err := DecodeVersions[string](&buffer, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validateSize(t, len(decoded), len(versions))
+	for i, version := range decoded {
+		validateList3(t, version, versions[i].Slice(0, versions[i].Size()))
+	}
+
+	calls := 0
+	decoded[0].Equal(decoded[1], func(a, b string) bool {
+		calls++
+		return a == b
+	})
+	if calls > defaultLeafCapacity {
+		t.Error("expected decoded versions to share nodes")
+	}
+}
+
+func TestDecodeVersionsErrors(t *testing.T) {
+	codec := WithCodec[string](StringCodec{})
+	invalid := [][]byte{
+		{},
+		{1, 9},
+		{1, leafNodeTag, 0},
+		{1, branchNodeTag, 1, 1},
+		{0, 1, 5},
+	}
+	// every branch doubles the size of the previous node until it overflows
+	overflow := []byte{70, leafNodeTag, 1, 1, 'a'}
+	for id := byte(1); id < 70; id++ {
+		overflow = append(overflow, branchNodeTag, id, id)
+	}
+	invalid = append(invalid, append(overflow, 1, 70))
+	for _, data := range invalid {
+		if _, err := DecodeVersions[string](bytes.NewReader(data), codec); err == nil {
+			t.Error(fmt.Sprintf("expected error for %v", data))
+		}
+	}
+	if _, err := EncodeVersions(&bytes.Buffer{}, []List[string]{createListForTest(1, 10)}); !errors.Is(err, ErrNoCodec) {
+		t.Error("expected ErrNoCodec for list without codec")
+	}
+	if _, err := DecodeVersions[string](bytes.NewReader([]byte{0})); !errors.Is(err, ErrNoCodec) {
+		t.Error("expected ErrNoCodec decoding without codec")
+	}
+}
+
+type otherStringCodec struct {
+	StringCodec
+}
+
+func TestEncodeVersionsRejectsMixedCodecs(t *testing.T) {
+	same := FromSlice([]string{"a"}, WithCodec[string](StringCodec{}))
+	other := FromSlice([]string{"b"}, WithCodec[string](otherStringCodec{}))
+	if _, err := EncodeVersions(&bytes.Buffer{}, []List[string]{same, same.Append("c")}); err != nil {
+		t.Error(err)
+	}
+	if _, err := EncodeVersions(&bytes.Buffer{}, []List[string]{same, other}); err == nil {
+		t.Error("expected error for versions with different codecs")
+	}
+}
@@ -1,17 +1,17 @@
 package immutableList
 
 // receive a value and return true to terminate loop or false to continue loop
-type VisitProc func(index int, value Object) bool
+type VisitProc[T any] func(index int, value T) bool
 
-type Visitable interface {
-	Visit(processor VisitProc)
+type Visitable[T any] interface {
+	Visit(processor VisitProc[T])
 }
 
-type ReduceProc func(acc Object, val Object) Object
+type ReduceProc[A any, T any] func(acc A, val T) A
 
-func Reduce(source Visitable, initialValue Object, proc ReduceProc) Object {
+func Reduce[T any, A any](source Visitable[T], initialValue A, proc ReduceProc[A, T]) A {
 	sum := initialValue
-	source.Visit(func(_ int, value Object) bool {
+	source.Visit(func(_ int, value T) bool {
 		sum = proc(sum, value)
 		return false
 	})